@@ -0,0 +1,192 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/generate"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+)
+
+// generateOptions are the flags of the `generate` subcommand.
+type generateOptions struct {
+	source  string
+	schema  string
+	openapi string
+	baseURL string
+	output  string
+}
+
+// newGenerateCommand returns the `generate` subcommand, which writes SQL tools
+// for the tables of a source, or http tools for the operations of an OpenAPI
+// document.
+func newGenerateCommand(cmd *Command) *cobra.Command {
+	var opts generateOptions
+	c := &cobra.Command{
+		Use:   "generate",
+		Short: "Generate tools from the schema of a database or an OpenAPI document",
+		Long: `Generate tools from the schema of a database or an OpenAPI document.
+
+Connects to a Postgres, MySQL, SQL Server or SQLite source configured in the
+tools file and reads its catalog. For each table, it writes tools to get a row
+by primary key, list rows filtered by their text columns, and search rows by
+each indexed column, with descriptions taken from table and column comments.
+The tools, and a toolset named after the source, are written as a tools file.
+
+With --openapi, it instead reads an OpenAPI 3 document and writes an http
+source named by --source, with one http tool for each operation. Path, query,
+header and JSON body parameters of the operations become the pathParams,
+queryParams, headerParams and bodyParams of the tools.`,
+		Example: `  toolbox generate --source my-pg-source --schema public --output generated.yaml
+  toolbox generate --source petstore --openapi petstore.yaml --output petstore-tools.yaml`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         func(c *cobra.Command, _ []string) error { return generateTools(c.Context(), cmd, opts) },
+	}
+	flags := c.Flags()
+	flags.StringVar(&opts.source, "source", "", "Name of the source to read the schema of.")
+	flags.StringVar(&opts.schema, "schema", "", "Schema to read. Defaults to 'public' for Postgres, 'dbo' for SQL Server, 'main' for SQLite and the database of the connection for MySQL.")
+	flags.StringVar(&opts.openapi, "openapi", "", "OpenAPI 3 document, in YAML or JSON, to generate http tools from.")
+	flags.StringVar(&opts.baseURL, "base-url", "", "Base URL of the generated http source. Defaults to the first server of the OpenAPI document.")
+	flags.StringVarP(&opts.output, "output", "o", "", "File to write the tools to. Defaults to stdout.")
+	_ = c.MarkFlagRequired("source")
+	c.MarkFlagsMutuallyExclusive("openapi", "schema")
+	return c
+}
+
+func generateTools(ctx context.Context, cmd *Command, opts generateOptions) error {
+	logger, err := log.NewStdLogger(cmd.outStream, cmd.errStream, "warn")
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	ctx = util.WithLogger(ctx, logger)
+	ctx = util.WithUserAgent(ctx, cmd.cfg.Version)
+
+	if opts.openapi != "" {
+		return generateOpenAPITools(cmd, opts)
+	}
+	if opts.baseURL != "" {
+		return fmt.Errorf("--base-url can only be used with --openapi")
+	}
+
+	paths, err := cmd.selectedToolsFiles(ctx)
+	if err != nil {
+		return err
+	}
+	env, err := cmd.selectedEnv()
+	if err != nil {
+		return err
+	}
+	toolsFile, err := loadToolsFiles(ctx, paths, env, cmd.secretResolvers)
+	if err != nil {
+		return err
+	}
+	sc, ok := toolsFile.Sources[opts.source]
+	if !ok {
+		return fmt.Errorf("no source named %q configured", opts.source)
+	}
+	s, err := sc.Initialize(ctx, otel.Tracer(server.TracerName))
+	if err != nil {
+		return fmt.Errorf("unable to initialize source %q: %w", opts.source, err)
+	}
+
+	d, err := generate.NewDialect(s)
+	if err != nil {
+		return err
+	}
+	schema := opts.schema
+	if schema == "" {
+		schema = d.DefaultSchema
+	}
+	tables, err := d.Tables(ctx, schema)
+	if err != nil {
+		return fmt.Errorf("unable to read the schema of source %q: %w", opts.source, err)
+	}
+	if len(tables) == 0 {
+		return fmt.Errorf("no tables found in source %q", opts.source)
+	}
+	b, err := generate.Marshal(opts.source, nil, generate.Tools(d, opts.source, tables))
+	if err != nil {
+		return fmt.Errorf("unable to marshal tools: %w", err)
+	}
+
+	if opts.output == "" {
+		_, err = cmd.outStream.Write(b)
+		return err
+	}
+	if err := writeGeneratedTools(opts.output, b); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.errStream, "Generated tools for %d table(s) in %q.\n", len(tables), opts.output)
+	return nil
+}
+
+// generateOpenAPITools writes an http source and its tools for the operations
+// of an OpenAPI document.
+func generateOpenAPITools(cmd *Command, opts generateOptions) error {
+	spec, err := os.ReadFile(opts.openapi)
+	if err != nil {
+		return fmt.Errorf("unable to read OpenAPI document: %w", err)
+	}
+	doc, err := generate.ParseOpenAPI(spec)
+	if err != nil {
+		return err
+	}
+	baseURL := opts.baseURL
+	if baseURL == "" {
+		baseURL = doc.BaseURL()
+	}
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		return fmt.Errorf("the OpenAPI document has no absolute server URL, set one with --base-url")
+	}
+
+	generated, skipped := generate.OpenAPITools(doc, opts.source)
+	for _, op := range skipped {
+		fmt.Fprintf(cmd.errStream, "Skipped operation %s %s: %s\n", op.Method, op.Path, op.Reason)
+	}
+	if len(generated) == 0 {
+		return fmt.Errorf("no operations found in OpenAPI document %q", opts.openapi)
+	}
+	b, err := generate.Marshal(opts.source, generate.HTTPSourceConfig{Kind: "http", BaseURL: baseURL}, generated)
+	if err != nil {
+		return fmt.Errorf("unable to marshal tools: %w", err)
+	}
+
+	if opts.output == "" {
+		_, err = cmd.outStream.Write(b)
+		return err
+	}
+	if err := writeGeneratedTools(opts.output, b); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.errStream, "Generated tools for %d operation(s) in %q.\n", len(generated), opts.output)
+	return nil
+}
+
+// writeGeneratedTools writes a generated tools file.
+func writeGeneratedTools(path string, b []byte) error {
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return fmt.Errorf("unable to write tools file: %w", err)
+	}
+	return nil
+}
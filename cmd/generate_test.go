@@ -0,0 +1,205 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// invokeGenerate runs `toolbox generate` with the given flags.
+func invokeGenerate(args []string) (string, string, error) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	c := NewCommand(WithStreams(stdout, stderr))
+	c.SilenceUsage = true
+	c.SetOut(stdout)
+	c.SetErr(stderr)
+	c.SetArgs(append([]string{"generate"}, args...))
+	err := c.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestGenerate(t *testing.T) {
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "shop.db")
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("unable to open database: %s", err)
+	}
+	for _, stmt := range []string{
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY, customer TEXT NOT NULL, total REAL)`,
+		`CREATE INDEX orders_by_customer ON orders (customer)`,
+		`INSERT INTO orders VALUES (1, 'alice', 10.5), (2, 'bob', 3), (3, 'alice', 7)`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("unable to create fixture: %s", err)
+		}
+	}
+	db.Close()
+
+	writeToolsFiles(t, dir, map[string]string{
+		"sources.yaml": `
+sources:
+	shop-db:
+		kind: sqlite
+		database: ` + dbPath + `
+`,
+	})
+	sourcesPath := filepath.Join(dir, "sources.yaml")
+	generatedPath := filepath.Join(dir, "generated.yaml")
+
+	_, stderr, err := invokeGenerate([]string{"--tools-file", sourcesPath, "--source", "shop-db", "--output", generatedPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stderr)
+	}
+	if want := "Generated tools for 1 table(s)"; !strings.Contains(stderr, want) {
+		t.Fatalf("unexpected output: got %q, want substring %q", stderr, want)
+	}
+
+	// the generated tools file must load and its tools must work
+	toolsFlags := []string{"--tools-file", sourcesPath, "--tools-file", generatedPath}
+	stdout, stderr, err := invokeTool(append([]string{"search-orders-by-customer", "--param", "customer=alice", "--param", "limit=10", "-o", "csv"}, toolsFlags...))
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stderr)
+	}
+	if want := "customer,id,total\nalice,1,10.5\nalice,3,7\n"; stdout != want {
+		t.Fatalf("unexpected output: got %q, want %q", stdout, want)
+	}
+	stdout, stderr, err = invokeTool(append([]string{"list-orders", "--param", "customer=b%", "--param", "limit=10", "-o", "csv"}, toolsFlags...))
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stderr)
+	}
+	if want := "customer,id,total\nbob,2,3\n"; stdout != want {
+		t.Fatalf("unexpected output: got %q, want %q", stdout, want)
+	}
+	stdout, _, err = invokeValidate(toolsFlags)
+	if err != nil {
+		t.Fatalf("generated tools file is invalid: %s", err)
+	}
+	if want := "3 tool(s), 1 toolset(s)"; !strings.Contains(stdout, want) {
+		t.Fatalf("unexpected output: got %q, want substring %q", stdout, want)
+	}
+}
+
+func TestGenerateOpenAPI(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"method": r.Method,
+			"path":   r.URL.Path,
+			"query":  r.URL.RawQuery,
+			"body":   string(body),
+		})
+	}))
+	defer ts.Close()
+
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "petstore.json")
+	spec := `{
+		"openapi": "3.0.0",
+		"servers": [{"url": "https://petstore.example.com"}],
+		"paths": {
+			"/pets/{petId}": {
+				"get": {
+					"operationId": "getPet",
+					"summary": "Get a pet.",
+					"parameters": [
+						{"name": "petId", "in": "path", "required": true, "schema": {"type": "string"}},
+						{"name": "fields", "in": "query", "required": true, "schema": {"type": "string"}}
+					]
+				}
+			},
+			"/pets": {
+				"post": {
+					"operationId": "createPet",
+					"summary": "Create a pet.",
+					"requestBody": {
+						"required": true,
+						"content": {"application/json": {"schema": {
+							"type": "object",
+							"required": ["name", "age"],
+							"properties": {"name": {"type": "string"}, "age": {"type": "integer"}}
+						}}}
+					}
+				}
+			}
+		}
+	}`
+	if err := os.WriteFile(specPath, []byte(spec), 0o644); err != nil {
+		t.Fatalf("unable to write OpenAPI document: %s", err)
+	}
+	generatedPath := filepath.Join(dir, "generated.yaml")
+
+	_, stderr, err := invokeGenerate([]string{"--source", "petstore", "--openapi", specPath, "--base-url", ts.URL, "--output", generatedPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stderr)
+	}
+	if want := "Generated tools for 2 operation(s)"; !strings.Contains(stderr, want) {
+		t.Fatalf("unexpected output: got %q, want substring %q", stderr, want)
+	}
+
+	toolsFlags := []string{"--tools-file", generatedPath}
+	stdout, stderr, err := invokeTool(append([]string{"get-pet", "--param", "petId=a b", "--param", "fields=name"}, toolsFlags...))
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stderr)
+	}
+	if want := `{\"body\":\"\",\"method\":\"GET\",\"path\":\"/pets/a b\",\"query\":\"fields=name\"}`; !strings.Contains(stdout, want) {
+		t.Fatalf("unexpected output: got %q, want substring %q", stdout, want)
+	}
+	stdout, stderr, err = invokeTool(append([]string{"create-pet", "--param", "name=Rex", "--param", "age=3"}, toolsFlags...))
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stderr)
+	}
+	if want := `\"body\":\"{\\\"age\\\": 3, \\\"name\\\": \\\"Rex\\\"}\",\"method\":\"POST\"`; !strings.Contains(stdout, want) {
+		t.Fatalf("unexpected output: got %q, want substring %q", stdout, want)
+	}
+	stdout, _, err = invokeValidate(toolsFlags)
+	if err != nil {
+		t.Fatalf("generated tools file is invalid: %s", err)
+	}
+	if want := "2 tool(s), 1 toolset(s)"; !strings.Contains(stdout, want) {
+		t.Fatalf("unexpected output: got %q, want substring %q", stdout, want)
+	}
+}
+
+func TestFailGenerate(t *testing.T) {
+	path := writeInvokeToolsFile(t)
+	tcs := []struct {
+		desc string
+		args []string
+		err  string
+	}{
+		{desc: "missing source", args: []string{"--source", "missing"}, err: `no source named "missing" configured`},
+		{desc: "no tables", args: []string{"--source", "my-sqlite"}, err: `no tables found in source "my-sqlite"`},
+		{desc: "base url without openapi", args: []string{"--source", "my-sqlite", "--base-url", "https://example.com"}, err: "--base-url can only be used with --openapi"},
+		{desc: "missing openapi document", args: []string{"--source", "petstore", "--openapi", "missing.yaml"}, err: "unable to read OpenAPI document"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, err := invokeGenerate(append(tc.args, "--tools-file", path))
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want substring %q", err, tc.err)
+			}
+		})
+	}
+}
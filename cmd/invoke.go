@@ -0,0 +1,341 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// invokeOptions are the flags of the `invoke` subcommand.
+type invokeOptions struct {
+	params     []string
+	paramsFile string
+	claims     []string
+	output     string
+}
+
+// newInvokeCommand returns the `invoke` subcommand, which invokes a single
+// tool without starting the server.
+func newInvokeCommand(cmd *Command) *cobra.Command {
+	var opts invokeOptions
+	c := &cobra.Command{
+		Use:   "invoke <tool>",
+		Short: "Invoke a tool without starting the server",
+		Long: `Invoke a tool without starting the server.
+
+Only the source used by the tool is initialized. Parameters are read from a
+JSON file given with --params-file and from --param flags, which take
+precedence. Values of parameters that are not strings are parsed as JSON.
+
+Authenticated parameters are populated from claims given with
+--claims <authService>=<JSON object>, or <authService>=@<file> to read the
+claims from a file. Claims are used as is, without verifying any token.`,
+		Example: `  toolbox invoke search-hotels --param name=Hilton --param limit=10
+  toolbox invoke list-my-orders --claims my-google-auth='{"sub": "123"}' --output table`,
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE:         func(c *cobra.Command, args []string) error { return invoke(c.Context(), cmd, args[0], opts) },
+	}
+	flags := c.Flags()
+	flags.StringArrayVar(&opts.params, "param", nil, "Parameter value as name=value. Can be repeated.")
+	flags.StringVar(&opts.paramsFile, "params-file", "", "JSON file containing an object of parameter values.")
+	flags.StringArrayVar(&opts.claims, "claims", nil, "Claims of an auth service as authService=<JSON object> or authService=@<file>. Can be repeated.")
+	flags.StringVarP(&opts.output, "output", "o", "json", "Output format. Allowed: 'json', 'table' or 'csv'.")
+	return c
+}
+
+func invoke(ctx context.Context, cmd *Command, toolName string, opts invokeOptions) error {
+	if !slices.Contains([]string{"json", "table", "csv"}, opts.output) {
+		return fmt.Errorf("invalid output format %q, must be one of: json, table, csv", opts.output)
+	}
+	logger, err := log.NewStdLogger(cmd.outStream, cmd.errStream, "warn")
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	ctx = util.WithLogger(ctx, logger)
+	ctx = util.WithUserAgent(ctx, cmd.cfg.Version)
+
+	paths, err := cmd.selectedToolsFiles(ctx)
+	if err != nil {
+		return err
+	}
+	env, err := cmd.selectedEnv()
+	if err != nil {
+		return err
+	}
+	toolsFile, err := loadToolsFiles(ctx, paths, env, cmd.secretResolvers)
+	if err != nil {
+		return err
+	}
+
+	tool, err := newToolInitializer(toolsFile).tool(ctx, toolName)
+	if err != nil {
+		return err
+	}
+
+	claimsMap, err := parseClaims(opts.claims, toolsFile)
+	if err != nil {
+		return err
+	}
+	data, err := parseParamFlags(tool.Manifest().Parameters, opts.paramsFile, opts.params)
+	if err != nil {
+		return err
+	}
+	res, err := runTool(ctx, toolName, tool, data, claimsMap)
+	if err != nil {
+		return err
+	}
+	return writeResult(cmd.outStream, opts.output, res)
+}
+
+// toolInitializer initializes tools on demand, along with the sources they
+// use. Sources are shared between the tools it initializes.
+type toolInitializer struct {
+	toolsFile ToolsFile
+	tracer    trace.Tracer
+	sources   map[string]sources.Source
+}
+
+func newToolInitializer(toolsFile ToolsFile) *toolInitializer {
+	return &toolInitializer{
+		toolsFile: toolsFile,
+		tracer:    otel.Tracer(server.TracerName),
+		sources:   make(map[string]sources.Source),
+	}
+}
+
+// tool initializes the named tool and the source it uses.
+func (i *toolInitializer) tool(ctx context.Context, toolName string) (tools.Tool, error) {
+	tc, ok := i.toolsFile.Tools[toolName]
+	if !ok {
+		return nil, fmt.Errorf("no tool named %q configured", toolName)
+	}
+
+	// tools that do not report their source get every source
+	sourceNames := slices.Sorted(maps.Keys(i.toolsFile.Sources))
+	if r, ok := tc.(tools.ReferencingToolConfig); ok {
+		sourceNames = nil
+		if name := r.ToolConfigReferences().Source; name != "" {
+			if _, ok := i.toolsFile.Sources[name]; !ok {
+				return nil, fmt.Errorf("tool %q: no source named %q configured", toolName, name)
+			}
+			sourceNames = append(sourceNames, name)
+		}
+	}
+
+	for _, name := range sourceNames {
+		if _, ok := i.sources[name]; ok {
+			continue
+		}
+		s, err := i.toolsFile.Sources[name].Initialize(ctx, i.tracer)
+		if err != nil {
+			return nil, fmt.Errorf("unable to initialize source %q: %w", name, err)
+		}
+		i.sources[name] = s
+	}
+
+	tool, err := tc.Initialize(i.sources)
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize tool %q: %w", toolName, err)
+	}
+	return tool, nil
+}
+
+// runTool checks that the claims authorize the invocation, then parses the
+// parameters and invokes the tool like the server does.
+func runTool(ctx context.Context, toolName string, tool tools.Tool, data map[string]any, claimsMap map[string]map[string]any) ([]any, error) {
+	if !tool.Authorized(slices.Sorted(maps.Keys(claimsMap))) {
+		return nil, fmt.Errorf("tool %q requires claims from one of its authRequired auth services", toolName)
+	}
+	params, err := tool.ParseParams(data, claimsMap)
+	if err != nil {
+		return nil, fmt.Errorf("provided parameters were invalid: %w", err)
+	}
+	res, err := tool.Invoke(util.WithClaims(ctx, claimsMap), params)
+	if err != nil {
+		return nil, fmt.Errorf("error while invoking tool: %w", err)
+	}
+	return res, nil
+}
+
+// parseClaims parses the --claims flags into claims keyed by auth service.
+func parseClaims(flags []string, toolsFile ToolsFile) (map[string]map[string]any, error) {
+	claimsMap := make(map[string]map[string]any, len(flags))
+	for _, f := range flags {
+		name, value, ok := strings.Cut(f, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid claims %q, must be authService=<JSON object>", f)
+		}
+		if !hasAuthService(toolsFile, name) {
+			return nil, fmt.Errorf("invalid claims %q: no auth service named %q configured", f, name)
+		}
+		raw := []byte(value)
+		if path, ok := strings.CutPrefix(value, "@"); ok {
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read claims file: %w", err)
+			}
+			raw = b
+		}
+		var claims map[string]any
+		if err := decodeJSONNumbers(raw, &claims); err != nil {
+			return nil, fmt.Errorf("invalid claims for %q: %w", name, err)
+		}
+		claimsMap[name] = claims
+	}
+	return claimsMap, nil
+}
+
+// hasAuthService reports whether the tools file configures the auth service,
+// including as a deprecated authSource.
+func hasAuthService(toolsFile ToolsFile, name string) bool {
+	_, isService := toolsFile.AuthServices[name]
+	_, isSource := toolsFile.AuthSources[name]
+	return isService || isSource
+}
+
+// parseParamFlags returns the parameter values from the params file, overridden
+// by the --param flags.
+func parseParamFlags(manifests []tools.ParameterManifest, paramsFile string, flags []string) (map[string]any, error) {
+	data := make(map[string]any)
+	if paramsFile != "" {
+		b, err := os.ReadFile(paramsFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read params file: %w", err)
+		}
+		if err := decodeJSONNumbers(b, &data); err != nil {
+			return nil, fmt.Errorf("params file %q is not a JSON object: %w", paramsFile, err)
+		}
+	}
+
+	paramTypes := make(map[string]string, len(manifests))
+	for _, m := range manifests {
+		paramTypes[m.Name] = m.Type
+	}
+	for _, f := range flags {
+		name, value, ok := strings.Cut(f, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid param %q, must be name=value", f)
+		}
+		t, ok := paramTypes[name]
+		if !ok {
+			return nil, fmt.Errorf("invalid param %q: the tool has no parameter named %q", f, name)
+		}
+		switch t {
+		case "string", "date", "timestamp", "duration":
+			data[name] = value
+			continue
+		}
+		var v any
+		if err := decodeJSONNumbers([]byte(value), &v); err != nil {
+			return nil, fmt.Errorf("invalid param %q: value of a %s parameter must be JSON: %w", f, t, err)
+		}
+		data[name] = v
+	}
+	return data, nil
+}
+
+// decodeJSONNumbers decodes JSON the same way as request bodies of the server,
+// keeping numbers as json.Number.
+func decodeJSONNumbers(b []byte, v any) error {
+	d := json.NewDecoder(bytes.NewReader(b))
+	d.UseNumber()
+	return d.Decode(v)
+}
+
+// writeResult writes the result of an invocation in the given format. Rows
+// that are not objects are written in a single "result" column.
+func writeResult(w io.Writer, format string, res []any) error {
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(res)
+	}
+
+	rows := make([]map[string]any, 0, len(res))
+	columnSet := make(map[string]bool)
+	for _, r := range res {
+		row, ok := r.(map[string]any)
+		if !ok {
+			row = map[string]any{"result": r}
+		}
+		for k := range row {
+			columnSet[k] = true
+		}
+		rows = append(rows, row)
+	}
+	columns := slices.Sorted(maps.Keys(columnSet))
+
+	records := make([][]string, 0, len(rows)+1)
+	records = append(records, columns)
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, c := range columns {
+			record[i] = formatCell(row[c])
+		}
+		records = append(records, record)
+	}
+
+	if format == "csv" {
+		cw := csv.NewWriter(w)
+		if err := cw.WriteAll(records); err != nil {
+			return fmt.Errorf("unable to write csv: %w", err)
+		}
+		return nil
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, record := range records {
+		fmt.Fprintln(tw, strings.Join(record, "\t"))
+	}
+	return tw.Flush()
+}
+
+// formatCell formats a value for a table or CSV cell. Nested values are
+// written as JSON.
+func formatCell(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case map[string]any, []any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// invokeTool runs `toolbox invoke` with the given flags.
+func invokeTool(args []string) (string, string, error) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	c := NewCommand(WithStreams(stdout, stderr))
+	c.SilenceUsage = true
+	c.SetOut(stdout)
+	c.SetErr(stderr)
+	c.SetArgs(append([]string{"invoke"}, args...))
+	err := c.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+// writeInvokeToolsFile writes a tools file whose tools query an SQLite
+// database that is created on first use.
+func writeInvokeToolsFile(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"tools.yaml": `
+sources:
+	my-sqlite:
+		kind: sqlite
+		database: ` + filepath.Join(dir, "test.db") + `
+	unreachable:
+		kind: postgres
+		host: 127.0.0.1
+		port: 1
+		database: db
+		user: user
+		password: password
+authServices:
+	my-google:
+		kind: google
+		clientId: my-client-id
+tools:
+	echo:
+		kind: sqlite-sql
+		source: my-sqlite
+		description: Echo the parameters.
+		statement: SELECT ? AS name, ? AS count, ? AS ratio;
+		parameters:
+			- name: name
+				type: string
+				description: A name.
+			- name: count
+				type: integer
+				description: A count.
+			- name: ratio
+				type: float
+				description: A ratio.
+	optional:
+		kind: sqlite-sql
+		source: my-sqlite
+		description: Echo optional parameters.
+		statement: SELECT COALESCE(?, 'any') AS name, ? AS lim;
+		parameters:
+			- name: name
+				type: string
+				description: A name.
+				required: false
+			- name: lim
+				type: integer
+				description: A limit.
+				default: 10
+	whoami:
+		kind: sqlite-sql
+		source: my-sqlite
+		description: Return the caller.
+		statement: SELECT ? AS email;
+		authRequired:
+			- my-google
+		parameters:
+			- name: email
+				type: string
+				description: The caller's email.
+				authServices:
+					- name: my-google
+						field: email
+`,
+	})
+	return filepath.Join(dir, "tools.yaml")
+}
+
+func TestInvoke(t *testing.T) {
+	path := writeInvokeToolsFile(t)
+	paramsFile := filepath.Join(t.TempDir(), "params.json")
+	if err := os.WriteFile(paramsFile, []byte(`{"name": "from-file", "count": 1, "ratio": 0.5}`), 0o600); err != nil {
+		t.Fatalf("unable to write params file: %s", err)
+	}
+
+	tcs := []struct {
+		desc string
+		args []string
+		want string
+	}{
+		{
+			desc: "json",
+			args: []string{"echo", "--param", "name=10", "--param", "count=10", "--param", "ratio=1.5"},
+			want: "[\n  {\n    \"count\": 10,\n    \"name\": \"10\",\n    \"ratio\": 1.5\n  }\n]\n",
+		},
+		{
+			desc: "table",
+			args: []string{"echo", "--param", "name=alice", "--param", "count=2", "--param", "ratio=0.25", "--output", "table"},
+			want: "count  name   ratio\n2      alice  0.25\n",
+		},
+		{
+			desc: "csv with params file",
+			args: []string{"echo", "--params-file", paramsFile, "--param", "name=a,b", "-o", "csv"},
+			want: "count,name,ratio\n1,\"a,b\",0.5\n",
+		},
+		{
+			desc: "optional parameters",
+			args: []string{"optional", "-o", "csv"},
+			want: "lim,name\n10,any\n",
+		},
+		{
+			desc: "claims",
+			args: []string{"whoami", "--claims", `my-google={"email": "alice@example.com"}`, "-o", "csv"},
+			want: "email\nalice@example.com\n",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			stdout, stderr, err := invokeTool(append(tc.args, "--tools-file", path))
+			if err != nil {
+				t.Fatalf("unexpected error: %s\n%s", err, stderr)
+			}
+			if stdout != tc.want {
+				t.Fatalf("unexpected output: got %q, want %q", stdout, tc.want)
+			}
+		})
+	}
+}
+
+func TestFailInvoke(t *testing.T) {
+	path := writeInvokeToolsFile(t)
+	tcs := []struct {
+		desc string
+		args []string
+		err  string
+	}{
+		{
+			desc: "missing tool",
+			args: []string{"missing"},
+			err:  `no tool named "missing" configured`,
+		},
+		{
+			desc: "unknown param",
+			args: []string{"echo", "--param", "other=1"},
+			err:  `invalid param "other=1": the tool has no parameter named "other"`,
+		},
+		{
+			desc: "invalid param value",
+			args: []string{"echo", "--param", "name=a", "--param", "count=one", "--param", "ratio=1"},
+			err:  `invalid param "count=one": value of a integer parameter must be JSON`,
+		},
+		{
+			desc: "missing param",
+			args: []string{"echo", "--param", "name=a"},
+			err:  `provided parameters were invalid: parameter "count" is required`,
+		},
+		{
+			desc: "unauthorized",
+			args: []string{"whoami"},
+			err:  `tool "whoami" requires claims from one of its authRequired auth services`,
+		},
+		{
+			desc: "unknown auth service",
+			args: []string{"whoami", "--claims", `other={"email": "a"}`},
+			err:  `no auth service named "other" configured`,
+		},
+		{
+			desc: "invalid output",
+			args: []string{"echo", "--output", "xml"},
+			err:  `invalid output format "xml"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, _, err := invokeTool(append(tc.args, "--tools-file", path))
+			if err == nil {
+				t.Fatalf("expect invoke to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want substring %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -16,6 +16,8 @@ package cmd
 
 import (
 	"io"
+
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 )
 
 // Option is a function that configures a Command.
@@ -28,3 +30,11 @@ func WithStreams(out, err io.Writer) Option {
 		c.errStream = err
 	}
 }
+
+// WithSecretResolver registers a resolver for ${scheme:ref} references in the
+// tools file, replacing any existing resolver for the scheme.
+func WithSecretResolver(scheme string, r secrets.Resolver) Option {
+	return func(c *Command) {
+		c.secretResolvers[scheme] = r
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+)
+
+// envFromEnvironment is the environment variable that selects the overlays to
+// apply when --env is not set.
+const envFromEnvironment = "TOOLBOX_ENV"
+
+var validEnvName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// selectedEnv returns the environment chosen by the --env flag or the
+// TOOLBOX_ENV environment variable, if any.
+func (cmd *Command) selectedEnv() (string, error) {
+	env := cmd.env
+	if env == "" {
+		env = os.Getenv(envFromEnvironment)
+	}
+	if env != "" && !validEnvName.MatchString(env) {
+		return "", fmt.Errorf("invalid environment %q: must only contain letters, digits, '-' and '_'", env)
+	}
+	return env, nil
+}
+
+// overlayPath returns the path of the overlay of a tools file for an
+// environment, e.g. "tools.prod.yaml" for "tools.yaml".
+func overlayPath(path, env string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + env + ext
+}
+
+// overlayBase reports whether the file at path is the overlay of another of
+// the given files, for any environment, and returns that file and the
+// environment.
+func overlayBase(path string, paths []string) (string, string, bool) {
+	ext := filepath.Ext(path)
+	stem := strings.TrimSuffix(path, ext)
+	i := strings.LastIndex(stem, ".")
+	if i < 0 || !validEnvName.MatchString(stem[i+1:]) {
+		return "", "", false
+	}
+	base := stem[:i] + ext
+	if !slices.Contains(paths, base) {
+		return "", "", false
+	}
+	return base, stem[i+1:], true
+}
+
+// readOverlay returns the contents of the overlay of a tools file, or nil if
+// the file has none.
+func readOverlay(path, env string) ([]byte, string, error) {
+	p := overlayPath(path, env)
+	b, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, p, nil
+	}
+	if err != nil {
+		return nil, p, fmt.Errorf("unable to read overlay at %q: %w", p, err)
+	}
+	return b, p, nil
+}
+
+// applyOverlay patches the contents of a tools file with an overlay, following
+// JSON Merge Patch (RFC 7386): mappings are merged recursively, null removes a
+// key, and any other value, including a list, replaces the base value.
+func applyOverlay(base, overlay []byte) ([]byte, error) {
+	baseDoc, err := decodeOrdered(base)
+	if err != nil {
+		return nil, err
+	}
+	patch, err := decodeOrdered(overlay)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse overlay: %w", err)
+	}
+	if patch == nil {
+		return base, nil
+	}
+	if _, ok := patch.(yaml.MapSlice); !ok {
+		return nil, fmt.Errorf("overlay must be a mapping")
+	}
+	return yaml.Marshal(mergePatch(baseDoc, patch))
+}
+
+// decodeOrdered decodes YAML, keeping the order of mapping keys.
+func decodeOrdered(b []byte) (any, error) {
+	var v any
+	dec := yaml.NewDecoder(bytes.NewReader(b), yaml.UseOrderedMap())
+	if err := dec.Decode(&v); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return v, nil
+}
+
+// mergePatch returns target patched by patch, following JSON Merge Patch.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(yaml.MapSlice)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(yaml.MapSlice)
+	if !ok {
+		t = nil
+	}
+	out := make(yaml.MapSlice, 0, len(t)+len(p))
+	out = append(out, t...)
+	for _, item := range p {
+		i := indexOfKey(out, item.Key)
+		switch {
+		case item.Value == nil && i >= 0:
+			out = append(out[:i], out[i+1:]...)
+		case item.Value == nil:
+		case i >= 0:
+			out[i].Value = mergePatch(out[i].Value, item.Value)
+		default:
+			out = append(out, yaml.MapItem{Key: item.Key, Value: mergePatch(nil, item.Value)})
+		}
+	}
+	return out
+}
+
+func indexOfKey(m yaml.MapSlice, key any) int {
+	for i, item := range m {
+		if item.Key == key {
+			return i
+		}
+	}
+	return -1
+}
@@ -19,19 +19,21 @@ import (
 	_ "embed"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"os/signal"
-	"regexp"
 	"strings"
 	"syscall"
 	"time"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	"github.com/googleapis/genai-toolbox/internal/telemetry"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -68,11 +70,16 @@ func Execute() {
 type Command struct {
 	*cobra.Command
 
-	cfg        server.ServerConfig
-	logger     log.Logger
-	tools_file string
-	outStream  io.Writer
-	errStream  io.Writer
+	cfg          server.ServerConfig
+	logger       log.Logger
+	tools_files  []string
+	tools_folder string
+	// env selects the overlays applied to the tools files.
+	env       string
+	outStream io.Writer
+	errStream io.Writer
+	// secretResolvers resolve ${scheme:ref} references in the tools file.
+	secretResolvers secrets.Resolvers
 }
 
 // NewCommand returns a Command object representing an invocation of the CLI.
@@ -86,9 +93,10 @@ func NewCommand(opts ...Option) *Command {
 		SilenceErrors: true,
 	}
 	cmd := &Command{
-		Command:   baseCmd,
-		outStream: out,
-		errStream: err,
+		Command:         baseCmd,
+		outStream:       out,
+		errStream:       err,
+		secretResolvers: secrets.DefaultResolvers(),
 	}
 
 	for _, o := range opts {
@@ -106,10 +114,13 @@ func NewCommand(opts ...Option) *Command {
 	flags.StringVarP(&cmd.cfg.Address, "address", "a", "127.0.0.1", "Address of the interface the server will listen on.")
 	flags.IntVarP(&cmd.cfg.Port, "port", "p", 5000, "Port the server will listen on.")
 
-	flags.StringVar(&cmd.tools_file, "tools_file", "tools.yaml", "File path specifying the tool configuration.")
-	// deprecate tools_file
-	_ = flags.MarkDeprecated("tools_file", "please use --tools-file instead")
-	flags.StringVar(&cmd.tools_file, "tools-file", "tools.yaml", "File path specifying the tool configuration.")
+	// tools file flags are shared with subcommands
+	persistentFlags := cmd.PersistentFlags()
+	persistentFlags.StringArrayVar(&cmd.tools_files, "tools-file", []string{"tools.yaml"}, "File path specifying the tool configuration. Can be repeated to merge multiple files.")
+	// accept the deprecated --tools_file spelling as an alias of --tools-file
+	cmd.SetGlobalNormalizationFunc(normalizeFlagName)
+	persistentFlags.StringVar(&cmd.tools_folder, "tools-folder", "", "Directory whose *.yaml files are merged into the tool configuration.")
+	persistentFlags.StringVar(&cmd.env, "env", "", "Environment whose overlays are applied to the tools files, e.g. 'prod' applies tools.prod.yaml to tools.yaml. Defaults to $TOOLBOX_ENV.")
 	flags.Var(&cmd.cfg.LogLevel, "log-level", "Specify the minimum level logged. Allowed: 'DEBUG', 'INFO', 'WARN', 'ERROR'.")
 	flags.Var(&cmd.cfg.LoggingFormat, "logging-format", "Specify logging format to use. Allowed: 'standard' or 'JSON'.")
 	flags.BoolVar(&cmd.cfg.TelemetryGCP, "telemetry-gcp", false, "Enable exporting directly to Google Cloud Monitoring.")
@@ -119,44 +130,66 @@ func NewCommand(opts ...Option) *Command {
 	// wrap RunE command so that we have access to original Command object
 	cmd.RunE = func(*cobra.Command, []string) error { return run(cmd) }
 
+	cmd.AddCommand(newValidateCommand(cmd))
+	cmd.AddCommand(newSchemaCommand(cmd))
+	cmd.AddCommand(newInvokeCommand(cmd))
+	cmd.AddCommand(newTestCommand(cmd))
+	cmd.AddCommand(newGenerateCommand(cmd))
+
 	return cmd
 }
 
+// normalizeFlagName maps deprecated flag names to their replacements.
+func normalizeFlagName(_ *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == "tools_file" {
+		name = "tools-file"
+	}
+	return pflag.NormalizedName(name)
+}
+
 type ToolsFile struct {
 	Sources      server.SourceConfigs      `yaml:"sources"`
 	AuthSources  server.AuthServiceConfigs `yaml:"authSources"` // Deprecated: Kept for compatibility.
 	AuthServices server.AuthServiceConfigs `yaml:"authServices"`
+	Templates    server.ToolTemplates      `yaml:"templates"`
 	Tools        server.ToolConfigs        `yaml:"tools"`
 	Toolsets     server.ToolsetConfigs     `yaml:"toolsets"`
 }
 
-// parseEnv replaces environment variables ${ENV_NAME} with their values.
-func parseEnv(input string) string {
-	re := regexp.MustCompile(`\$\{(\w+)\}`)
-
-	return re.ReplaceAllStringFunc(input, func(match string) string {
-		parts := re.FindStringSubmatch(match)
-		if len(parts) < 2 {
-			// technically shouldn't happen
-			return match
-		}
-
-		// extract the variable name
-		variableName := parts[1]
-		if value, found := os.LookupEnv(variableName); found {
-			return value
-		}
-		return match
-	})
+// parseToolsFile parses the provided yaml into appropriate configs.
+func parseToolsFile(ctx context.Context, raw []byte, resolvers secrets.Resolvers) (ToolsFile, error) {
+	var toolsFile ToolsFile
+	// Replace environment variables and secret references
+	expanded, err := resolvers.Expand(ctx, string(raw))
+	if err != nil {
+		return toolsFile, fmt.Errorf("unable to resolve references: %w", err)
+	}
+	return decodeToolsFile(ctx, []byte(expanded))
 }
 
-// parseToolsFile parses the provided yaml into appropriate configs.
-func parseToolsFile(ctx context.Context, raw []byte) (ToolsFile, error) {
+// decodeToolsFile decodes tools file contents whose references have already
+// been expanded. Tools may extend the templates of this file or those already
+// in ctx.
+func decodeToolsFile(ctx context.Context, b []byte) (ToolsFile, error) {
 	var toolsFile ToolsFile
-	// Replace environment variables if found
-	raw = []byte(parseEnv(string(raw)))
+	// templates must be known before any tool can be decoded
+	var t struct {
+		Templates server.ToolTemplates `yaml:"templates"`
+	}
+	if err := yaml.UnmarshalContext(ctx, b, &t); err != nil {
+		return toolsFile, err
+	}
+	if len(t.Templates) > 0 {
+		templates := maps.Clone(server.ToolTemplatesFromContext(ctx))
+		if templates == nil {
+			templates = make(server.ToolTemplates)
+		}
+		maps.Copy(templates, t.Templates)
+		ctx = server.WithToolTemplates(ctx, templates)
+	}
+
 	// Parse contents
-	err := yaml.UnmarshalContext(ctx, raw, &toolsFile, yaml.Strict())
+	err := yaml.UnmarshalContext(ctx, b, &toolsFile, yaml.Strict())
 	if err != nil {
 		return toolsFile, err
 	}
@@ -222,24 +255,32 @@ func run(cmd *Command) error {
 		}
 	}()
 
-	// Read tool file contents
-	buf, err := os.ReadFile(cmd.tools_file)
+	// Read and merge tool file contents
+	paths, err := cmd.selectedToolsFiles(ctx)
 	if err != nil {
-		errMsg := fmt.Errorf("unable to read tool file at %q: %w", cmd.tools_file, err)
-		cmd.logger.ErrorContext(ctx, errMsg.Error())
-		return errMsg
+		cmd.logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+	env, err := cmd.selectedEnv()
+	if err != nil {
+		cmd.logger.ErrorContext(ctx, err.Error())
+		return err
+	}
+	toolsFile, err := loadToolsFiles(ctx, paths, env, cmd.secretResolvers)
+	if err != nil {
+		cmd.logger.ErrorContext(ctx, err.Error())
+		return err
 	}
-	toolsFile, err := parseToolsFile(ctx, buf)
 	cmd.cfg.SourceConfigs, cmd.cfg.AuthServiceConfigs, cmd.cfg.ToolConfigs, cmd.cfg.ToolsetConfigs = toolsFile.Sources, toolsFile.AuthServices, toolsFile.Tools, toolsFile.Toolsets
 	authSourceConfigs := toolsFile.AuthSources
 	if authSourceConfigs != nil {
 		cmd.logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` instead")
-		cmd.cfg.AuthServiceConfigs = authSourceConfigs
-	}
-	if err != nil {
-		errMsg := fmt.Errorf("unable to parse tool file at %q: %w", cmd.tools_file, err)
-		cmd.logger.ErrorContext(ctx, errMsg.Error())
-		return errMsg
+		if cmd.cfg.AuthServiceConfigs == nil {
+			cmd.cfg.AuthServiceConfigs = make(server.AuthServiceConfigs)
+		}
+		for name, a := range authSourceConfigs {
+			cmd.cfg.AuthServiceConfigs[name] = a
+		}
 	}
 
 	// start server
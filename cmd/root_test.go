@@ -24,6 +24,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/googleapis/genai-toolbox/internal/auth/google"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
 	"github.com/googleapis/genai-toolbox/internal/server"
 	cloudsqlpgsrc "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
@@ -180,29 +181,46 @@ func TestServerConfigFlags(t *testing.T) {
 
 func TestToolFileFlag(t *testing.T) {
 	tcs := []struct {
-		desc string
-		args []string
-		want string
+		desc       string
+		args       []string
+		want       []string
+		wantFolder string
 	}{
 		{
 			desc: "default value",
 			args: []string{},
-			want: "tools.yaml",
+			want: []string{"tools.yaml"},
 		},
 		{
 			desc: "foo file",
 			args: []string{"--tools-file", "foo.yaml"},
-			want: "foo.yaml",
+			want: []string{"foo.yaml"},
 		},
 		{
 			desc: "address long",
 			args: []string{"--tools-file", "bar.yaml"},
-			want: "bar.yaml",
+			want: []string{"bar.yaml"},
 		},
 		{
 			desc: "deprecated flag",
 			args: []string{"--tools_file", "foo.yaml"},
-			want: "foo.yaml",
+			want: []string{"foo.yaml"},
+		},
+		{
+			desc: "repeated flag",
+			args: []string{"--tools-file", "foo.yaml", "--tools-file", "bar.yaml"},
+			want: []string{"foo.yaml", "bar.yaml"},
+		},
+		{
+			desc: "deprecated and current flag",
+			args: []string{"--tools_file", "foo.yaml", "--tools-file", "bar.yaml"},
+			want: []string{"foo.yaml", "bar.yaml"},
+		},
+		{
+			desc:       "tools folder",
+			args:       []string{"--tools-folder", "tools"},
+			want:       []string{"tools.yaml"},
+			wantFolder: "tools",
 		},
 	}
 	for _, tc := range tcs {
@@ -211,8 +229,11 @@ func TestToolFileFlag(t *testing.T) {
 			if err != nil {
 				t.Fatalf("unexpected error invoking command: %s", err)
 			}
-			if c.tools_file != tc.want {
-				t.Fatalf("got %v, want %v", c.cfg, tc.want)
+			if diff := cmp.Diff(tc.want, c.tools_files); diff != "" {
+				t.Fatalf("incorrect tools files: diff %v", diff)
+			}
+			if c.tools_folder != tc.wantFolder {
+				t.Fatalf("got %v, want %v", c.tools_folder, tc.wantFolder)
 			}
 		})
 	}
@@ -340,7 +361,7 @@ func TestParseToolFile(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
-			toolsFile, err := parseToolsFile(ctx, testutils.FormatYaml(tc.in))
+			toolsFile, err := parseToolsFile(ctx, testutils.FormatYaml(tc.in), secrets.DefaultResolvers())
 			if err != nil {
 				t.Fatalf("failed to parse input: %v", err)
 			}
@@ -570,7 +591,7 @@ func TestParseToolFileWithAuth(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
-			toolsFile, err := parseToolsFile(ctx, testutils.FormatYaml(tc.in))
+			toolsFile, err := parseToolsFile(ctx, testutils.FormatYaml(tc.in), secrets.DefaultResolvers())
 			if err != nil {
 				t.Fatalf("failed to parse input: %v", err)
 			}
@@ -734,7 +755,7 @@ func TestEnvVarReplacement(t *testing.T) {
 	}
 	for _, tc := range tcs {
 		t.Run(tc.description, func(t *testing.T) {
-			toolsFile, err := parseToolsFile(ctx, testutils.FormatYaml(tc.in))
+			toolsFile, err := parseToolsFile(ctx, testutils.FormatYaml(tc.in), secrets.DefaultResolvers())
 			if err != nil {
 				t.Fatalf("failed to parse input: %v", err)
 			}
@@ -754,3 +775,29 @@ func TestEnvVarReplacement(t *testing.T) {
 	}
 
 }
+
+func TestFailEnvVarReplacement(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+	sources:
+		my-pg-instance:
+			kind: postgres
+			host: ${PG_HOST:-127.0.0.1}
+			port: 5432
+			user: ${TOOLBOX_TEST_UNSET_USER}
+			password: ${TOOLBOX_TEST_UNSET_PASSWORD}
+			database: toolbox
+	`
+	_, err = parseToolsFile(ctx, testutils.FormatYaml(in), secrets.DefaultResolvers())
+	if err == nil {
+		t.Fatalf("expect parsing to fail")
+	}
+	for _, want := range []string{`"TOOLBOX_TEST_UNSET_USER" is not set`, `"TOOLBOX_TEST_UNSET_PASSWORD" is not set`} {
+		if !strings.Contains(err.Error(), want) {
+			t.Fatalf("unexpected error: got %q, want substring %q", err, want)
+		}
+	}
+}
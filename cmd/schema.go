@@ -0,0 +1,284 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/spf13/cobra"
+)
+
+// newSchemaCommand returns the `schema` subcommand, which prints a JSON Schema
+// describing tools files.
+func newSchemaCommand(cmd *Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print a JSON Schema describing tools files",
+		Long: `Print a JSON Schema describing tools files.
+
+The schema is generated from every registered kind of source, auth service and
+tool, and from every parameter type. Point your editor at it to get
+autocompletion and inline validation while authoring tools files.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(*cobra.Command, []string) error {
+			schema, err := toolsFileSchema()
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(cmd.outStream)
+			enc.SetIndent("", "  ")
+			return enc.Encode(schema)
+		},
+	}
+}
+
+// jsonSchema is a JSON Schema or subschema.
+type jsonSchema = map[string]any
+
+var (
+	sourceConfigType      = reflect.TypeFor[sources.SourceConfig]()
+	authServiceConfigType = reflect.TypeFor[auth.AuthServiceConfig]()
+	toolConfigType        = reflect.TypeFor[tools.ToolConfig]()
+	toolsetConfigType     = reflect.TypeFor[tools.ToolsetConfig]()
+	parameterType         = reflect.TypeFor[tools.Parameter]()
+	toolTemplatesType     = reflect.TypeFor[server.ToolTemplates]()
+)
+
+// toolsFileSchema generates a JSON Schema for ToolsFile.
+func toolsFileSchema() (jsonSchema, error) {
+	g := &schemaGenerator{defs: make(map[string]any)}
+
+	if err := defineKinds(g, "source", sources.Kinds(), sources.DefaultConfig); err != nil {
+		return nil, err
+	}
+	if err := defineKinds(g, "authService", auth.Kinds(), auth.DefaultConfig); err != nil {
+		return nil, err
+	}
+	if err := defineKinds(g, "tool", tools.Kinds(), tools.DefaultConfig); err != nil {
+		return nil, err
+	}
+	// tools that extend a template may inherit any field, including their kind
+	g.defs["toolTemplate"] = jsonSchema{
+		"type":       "object",
+		"properties": jsonSchema{"extends": jsonSchema{"type": "string"}},
+	}
+	g.defs["tool"] = jsonSchema{
+		"if":   jsonSchema{"required": []string{"extends"}},
+		"then": ref("toolTemplate"),
+		"else": g.defs["tool"],
+	}
+	params := tools.ParameterTypes()
+	paramTypes := slices.Sorted(maps.Keys(params))
+	variants := make(map[string]reflect.Value, len(params))
+	for t, p := range params {
+		variants[t] = reflect.ValueOf(p)
+	}
+	g.defineVariants("parameter", "type", paramTypes, variants, nil)
+
+	s := g.typeSchema(reflect.TypeFor[ToolsFile]())
+	s["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	s["title"] = "Toolbox tools file"
+	s["$defs"] = g.defs
+	return s, nil
+}
+
+// defineKinds adds a definition for every registered kind of resource, based
+// on its default configuration.
+func defineKinds[T any](g *schemaGenerator, name string, kinds []string, defaultConfig func(string) (T, error)) error {
+	variants := make(map[string]reflect.Value, len(kinds))
+	for _, kind := range kinds {
+		cfg, err := defaultConfig(kind)
+		if err != nil {
+			return fmt.Errorf("unable to inspect %s kind %q: %w", name, kind, err)
+		}
+		variants[kind] = reflect.ValueOf(cfg)
+	}
+	// the name is taken from the key the resource is defined under
+	g.defineVariants(name, "kind", kinds, variants, []string{"name"})
+	return nil
+}
+
+type schemaGenerator struct {
+	defs map[string]any
+}
+
+func ref(name string) jsonSchema {
+	return jsonSchema{"$ref": "#/$defs/" + name}
+}
+
+// defineVariants defines name as an object whose shape is selected by the
+// value of its discriminator field, with one definition per variant.
+func (g *schemaGenerator) defineVariants(name, discriminator string, values []string, variants map[string]reflect.Value, skip []string) {
+	allOf := make([]any, 0, len(values))
+	for _, v := range values {
+		variantName := name + "." + v
+		s := g.structSchema(variants[v], skip)
+		s["properties"].(map[string]any)[discriminator] = jsonSchema{"const": v}
+		g.defs[variantName] = s
+		allOf = append(allOf, jsonSchema{
+			"if":   jsonSchema{"properties": jsonSchema{discriminator: jsonSchema{"const": v}}, "required": []string{discriminator}},
+			"then": ref(variantName),
+		})
+	}
+	g.defs[name] = jsonSchema{
+		"type":       "object",
+		"required":   []string{discriminator},
+		"properties": jsonSchema{discriminator: jsonSchema{"enum": values}},
+		"allOf":      allOf,
+	}
+}
+
+// typeSchema returns the schema for values of type t.
+func (g *schemaGenerator) typeSchema(t reflect.Type) jsonSchema {
+	switch t {
+	case sourceConfigType:
+		return ref("source")
+	case authServiceConfigType:
+		return ref("authService")
+	case toolConfigType:
+		return ref("tool")
+	case parameterType:
+		return ref("parameter")
+	case toolTemplatesType:
+		return jsonSchema{"type": "object", "additionalProperties": ref("toolTemplate")}
+	case toolsetConfigType:
+		// toolsets are decoded from a list of tool names, glob patterns and
+		// single-key selectors
+		selector := func(keys ...string) []any {
+			out := make([]any, 0, len(keys))
+			for _, k := range keys {
+				out = append(out, jsonSchema{
+					"type":                 "object",
+					"required":             []string{k},
+					"properties":           jsonSchema{k: jsonSchema{"type": "string"}},
+					"additionalProperties": false,
+				})
+			}
+			return out
+		}
+		keys := []string{"tool", "toolset", "regex", "kind", "source"}
+		entry := append([]any{jsonSchema{"type": "string"}}, selector(keys...)...)
+		exclude := jsonSchema{
+			"type":                 "object",
+			"required":             []string{"exclude"},
+			"properties":           jsonSchema{"exclude": jsonSchema{"anyOf": slices.Clone(entry)}},
+			"additionalProperties": false,
+		}
+		return jsonSchema{"type": "array", "items": jsonSchema{"anyOf": append(entry, exclude)}}
+	}
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.typeSchema(t.Elem())
+	case reflect.String:
+		return jsonSchema{"type": "string"}
+	case reflect.Bool:
+		return jsonSchema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return jsonSchema{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return jsonSchema{"type": "integer", "minimum": 0}
+	case reflect.Float32, reflect.Float64:
+		return jsonSchema{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return jsonSchema{"type": "array", "items": g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return jsonSchema{"type": "object", "additionalProperties": g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return g.structSchema(reflect.New(t).Elem(), nil)
+	default:
+		// any value is accepted
+		return jsonSchema{}
+	}
+}
+
+// structSchema returns the schema for the struct held by v, using the
+// non-zero values of v as defaults. Fields named in skip are left out.
+func (g *schemaGenerator) structSchema(v reflect.Value, skip []string) jsonSchema {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	properties := make(map[string]any)
+	var required []string
+	g.addFields(v, skip, properties, &required)
+	s := jsonSchema{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	return s
+}
+
+func (g *schemaGenerator) addFields(v reflect.Value, skip []string, properties map[string]any, required *[]string) {
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if slices.Contains(strings.Split(opts, ","), "inline") {
+			g.addFields(v.Field(i), skip, properties, required)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(f.Name)
+		}
+		if slices.Contains(skip, name) {
+			continue
+		}
+
+		s := g.typeSchema(f.Type)
+		for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+			switch {
+			case rule == "required":
+				*required = append(*required, name)
+			case strings.HasPrefix(rule, "oneof="):
+				s["enum"] = strings.Fields(strings.TrimPrefix(rule, "oneof="))
+			}
+		}
+		if fv := v.Field(i); !fv.IsZero() && isScalar(fv.Kind()) {
+			s["default"] = fv.Interface()
+		}
+		properties[name] = s
+	}
+}
+
+func isScalar(k reflect.Kind) bool {
+	switch k {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestSchema(t *testing.T) {
+	stdout := new(bytes.Buffer)
+	c := NewCommand(WithStreams(stdout, new(bytes.Buffer)))
+	c.SetArgs([]string{"schema"})
+	if err := c.Execute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+		t.Fatalf("schema is not valid JSON: %s", err)
+	}
+	defs := got["$defs"].(map[string]any)
+
+	for _, kind := range sources.Kinds() {
+		if _, ok := defs["source."+kind]; !ok {
+			t.Errorf("missing definition for source kind %q", kind)
+		}
+	}
+	for _, kind := range tools.Kinds() {
+		if _, ok := defs["tool."+kind]; !ok {
+			t.Errorf("missing definition for tool kind %q", kind)
+		}
+	}
+	for paramType := range tools.ParameterTypes() {
+		if _, ok := defs["parameter."+paramType]; !ok {
+			t.Errorf("missing definition for parameter type %q", paramType)
+		}
+	}
+
+	tcs := []struct {
+		desc string
+		got  any
+		want any
+	}{
+		{
+			desc: "tools reference the tool definition",
+			got:  got["properties"].(map[string]any)["tools"],
+			want: map[string]any{"type": "object", "additionalProperties": map[string]any{"$ref": "#/$defs/tool"}},
+		},
+		{
+			desc: "required fields come from validate tags",
+			got:  defs["tool.postgres-sql"].(map[string]any)["required"],
+			want: []any{"kind", "source", "description", "statement"},
+		},
+		{
+			desc: "kind is fixed per definition",
+			got:  defs["tool.postgres-sql"].(map[string]any)["properties"].(map[string]any)["kind"],
+			want: map[string]any{"const": "postgres-sql"},
+		},
+		{
+			desc: "parameters reference the parameter definition",
+			got:  defs["tool.postgres-sql"].(map[string]any)["properties"].(map[string]any)["parameters"],
+			want: map[string]any{"type": "array", "items": map[string]any{"$ref": "#/$defs/parameter"}},
+		},
+		{
+			desc: "defaults come from the default config",
+			got:  defs["source.http"].(map[string]any)["properties"].(map[string]any)["timeout"],
+			want: map[string]any{"type": "string", "default": "30s"},
+		},
+		{
+			desc: "array items are parameters",
+			got:  defs["parameter.array"].(map[string]any)["properties"].(map[string]any)["items"],
+			want: map[string]any{"$ref": "#/$defs/parameter"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.got); diff != "" {
+				t.Fatalf("incorrect schema (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// toolsFilePaths returns the tools files to load, in order. Files in the tools
+// folder are loaded after any explicitly provided tools files. Overlays in the
+// folder, such as "tools.prod.yaml" next to "tools.yaml", are only applied to
+// their base file and are not loaded on their own, which is logged since any
+// file named "<base>.<suffix>.yaml" is taken for an overlay.
+func toolsFilePaths(ctx context.Context, files []string, folder string) ([]string, error) {
+	paths := slices.Clone(files)
+	if folder == "" {
+		return paths, nil
+	}
+	matches, err := filepath.Glob(filepath.Join(folder, "*.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list tools folder %q: %w", folder, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no *.yaml files found in tools folder %q", folder)
+	}
+	slices.Sort(matches)
+	logger, _ := util.LoggerFromContext(ctx)
+	for _, m := range matches {
+		base, env, ok := overlayBase(m, matches)
+		if !ok {
+			paths = append(paths, m)
+			continue
+		}
+		if logger != nil {
+			logger.InfoContext(ctx, fmt.Sprintf("Not loading %s on its own: it is the overlay of %s for environment %s.", m, base, env))
+		}
+	}
+	return paths, nil
+}
+
+// selectedToolsFiles returns the tools files chosen by the --tools-file and
+// --tools-folder flags.
+func (cmd *Command) selectedToolsFiles(ctx context.Context) ([]string, error) {
+	toolsFiles := cmd.tools_files
+	flags := cmd.PersistentFlags()
+	if cmd.tools_folder != "" && !flags.Changed("tools-file") {
+		// only load the default tools file when no folder is given
+		toolsFiles = nil
+	}
+	return toolsFilePaths(ctx, toolsFiles, cmd.tools_folder)
+}
+
+// readToolsFile returns the contents of a tools file with references resolved.
+// If env is set and the file has an overlay for that environment, the overlay
+// is applied first, so that values it replaces need not be resolvable. The
+// path of the applied overlay, if any, is returned.
+func readToolsFile(ctx context.Context, path, env string, resolvers secrets.Resolvers) ([]byte, string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to read tool file at %q: %w", path, err)
+	}
+	var applied string
+	if env != "" {
+		overlay, overlayPath, err := readOverlay(path, env)
+		if err != nil {
+			return nil, "", err
+		}
+		if overlay != nil {
+			if contents, err = applyOverlay(contents, overlay); err != nil {
+				return nil, "", fmt.Errorf("unable to apply overlay %q to tool file at %q: %w", overlayPath, path, err)
+			}
+			applied = overlayPath
+		}
+	}
+	expanded, err := resolvers.Expand(ctx, string(contents))
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to parse tool file at %q: unable to resolve references: %w", path, err)
+	}
+	return []byte(expanded), applied, nil
+}
+
+// loadToolsFiles reads, parses and merges the given tools files. Tools may
+// extend templates defined in any of the files. If env is set, the overlay of
+// each file for that environment is applied before the file is decoded.
+func loadToolsFiles(ctx context.Context, paths []string, env string, resolvers secrets.Resolvers) (ToolsFile, error) {
+	contents := make([][]byte, len(paths))
+	templates := newToolsFileMerger()
+	overlays := 0
+	for i, path := range paths {
+		var overlay string
+		var err error
+		if contents[i], overlay, err = readToolsFile(ctx, path, env, resolvers); err != nil {
+			return ToolsFile{}, err
+		}
+		if overlay != "" {
+			overlays++
+		}
+
+		var t struct {
+			Templates server.ToolTemplates `yaml:"templates"`
+		}
+		if err := yaml.UnmarshalContext(ctx, contents[i], &t); err != nil {
+			return ToolsFile{}, fmt.Errorf("unable to parse tool file at %q: %w", path, err)
+		}
+		if err := templates.merge(path, ToolsFile{Templates: t.Templates}); err != nil {
+			return ToolsFile{}, err
+		}
+	}
+	if env != "" && overlays == 0 {
+		return ToolsFile{}, fmt.Errorf("no overlays found for environment %q", env)
+	}
+	ctx = server.WithToolTemplates(ctx, templates.result.Templates)
+
+	m := newToolsFileMerger()
+	for i, path := range paths {
+		toolsFile, err := decodeToolsFile(ctx, contents[i])
+		if err != nil {
+			return ToolsFile{}, fmt.Errorf("unable to parse tool file at %q: %w", path, err)
+		}
+		if err := m.merge(path, toolsFile); err != nil {
+			return ToolsFile{}, err
+		}
+	}
+	return m.result, nil
+}
+
+// toolsFileMerger merges tools files, remembering which file defined each
+// resource so that duplicates can be reported with both locations.
+type toolsFileMerger struct {
+	result  ToolsFile
+	origins map[string]map[string]string
+}
+
+func newToolsFileMerger() *toolsFileMerger {
+	return &toolsFileMerger{
+		origins: map[string]map[string]string{
+			"source":      {},
+			"authService": {},
+			"tool":        {},
+			"toolset":     {},
+			"template":    {},
+		},
+	}
+}
+
+func (m *toolsFileMerger) merge(path string, f ToolsFile) error {
+	var err error
+	if m.result.Sources, err = mergeConfigs(m, "source", path, m.result.Sources, f.Sources); err != nil {
+		return err
+	}
+	if m.result.AuthServices, err = mergeConfigs(m, "authService", path, m.result.AuthServices, f.AuthServices); err != nil {
+		return err
+	}
+	// deprecated authSources share a namespace with authServices
+	if m.result.AuthSources, err = mergeConfigs(m, "authService", path, m.result.AuthSources, f.AuthSources); err != nil {
+		return err
+	}
+	if m.result.Templates, err = mergeConfigs(m, "template", path, m.result.Templates, f.Templates); err != nil {
+		return err
+	}
+	if m.result.Tools, err = mergeConfigs(m, "tool", path, m.result.Tools, f.Tools); err != nil {
+		return err
+	}
+	if m.result.Toolsets, err = mergeConfigs(m, "toolset", path, m.result.Toolsets, f.Toolsets); err != nil {
+		return err
+	}
+	return nil
+}
+
+// mergeConfigs copies src into dst, returning an error if a name is already defined.
+func mergeConfigs[M ~map[string]V, V any](m *toolsFileMerger, resource, path string, dst, src M) (M, error) {
+	if src == nil {
+		return dst, nil
+	}
+	if dst == nil {
+		dst = make(M, len(src))
+	}
+	// iterate in a stable order so the reported duplicate is deterministic
+	names := make([]string, 0, len(src))
+	for name := range src {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		if prev, ok := m.origins[resource][name]; ok {
+			return nil, fmt.Errorf("duplicate %s %q: defined in both %q and %q", resource, name, prev, path)
+		}
+		m.origins[resource][name] = path
+		dst[name] = src[name]
+	}
+	return dst, nil
+}
@@ -0,0 +1,326 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+// writeToolsFiles writes each named file into dir.
+func writeToolsFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), testutils.FormatYaml(content), 0o600); err != nil {
+			t.Fatalf("unable to write %s: %s", name, err)
+		}
+	}
+}
+
+var ordersToolsFile = `
+sources:
+	orders-db:
+		kind: sqlite
+		database: orders.db
+tools:
+	list-orders:
+		kind: sqlite-sql
+		source: orders-db
+		description: List orders.
+		statement: SELECT * FROM orders;
+toolsets:
+	orders:
+		- list-orders
+`
+
+var usersToolsFile = `
+sources:
+	users-db:
+		kind: sqlite
+		database: users.db
+tools:
+	list-users:
+		kind: sqlite-sql
+		source: users-db
+		description: List users.
+		statement: SELECT * FROM users;
+`
+
+func TestLoadToolsFiles(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"orders.yaml": ordersToolsFile,
+		"users.yaml":  usersToolsFile,
+		"notes.txt":   "not a tools file",
+	})
+
+	paths, err := toolsFilePaths(ctx, nil, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantPaths := []string{filepath.Join(dir, "orders.yaml"), filepath.Join(dir, "users.yaml")}
+	if diff := cmp.Diff(wantPaths, paths); diff != "" {
+		t.Fatalf("incorrect paths: diff %v", diff)
+	}
+
+	got, err := loadToolsFiles(ctx, paths, "", secrets.DefaultResolvers())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, name := range []string{"orders-db", "users-db"} {
+		if _, ok := got.Sources[name]; !ok {
+			t.Fatalf("missing source %q", name)
+		}
+	}
+	for _, name := range []string{"list-orders", "list-users"} {
+		if _, ok := got.Tools[name]; !ok {
+			t.Fatalf("missing tool %q", name)
+		}
+	}
+	wantToolsets := server.ToolsetConfigs{
+		"orders": tools.ToolsetConfig{Name: "orders", ToolNames: []string{"list-orders"}},
+	}
+	if diff := cmp.Diff(wantToolsets, got.Toolsets); diff != "" {
+		t.Fatalf("incorrect toolsets: diff %v", diff)
+	}
+}
+
+func TestFailLoadToolsFiles(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"orders.yaml":           ordersToolsFile,
+		"orders-duplicate.yaml": ordersToolsFile,
+	})
+	a := filepath.Join(dir, "orders-duplicate.yaml")
+	b := filepath.Join(dir, "orders.yaml")
+
+	_, err = loadToolsFiles(ctx, []string{a, b}, "", secrets.DefaultResolvers())
+	if err == nil {
+		t.Fatalf("expect loading to fail")
+	}
+	want := `duplicate source "orders-db": defined in both "` + a + `" and "` + b + `"`
+	if err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err, want)
+	}
+
+	_, err = toolsFilePaths(ctx, nil, t.TempDir())
+	if err == nil || !strings.Contains(err.Error(), "no *.yaml files found") {
+		t.Fatalf("expect empty tools folder to fail, got %v", err)
+	}
+}
+
+func TestLoadToolsFilesWithTemplates(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"base.yaml": `
+sources:
+	orders-db:
+		kind: sqlite
+		database: orders.db
+templates:
+	orders-tool:
+		kind: sqlite-sql
+		source: orders-db
+		parameters:
+			- name: customer
+				type: string
+				description: The customer.
+`,
+		"orders.yaml": `
+tools:
+	list-orders:
+		extends: orders-tool
+		description: List orders.
+		statement: SELECT * FROM orders WHERE customer = ? LIMIT ?;
+		parameters:
+			- name: limit
+				type: integer
+				description: Max rows.
+`,
+	})
+
+	got, err := loadToolsFiles(ctx, []string{filepath.Join(dir, "base.yaml"), filepath.Join(dir, "orders.yaml")}, "", secrets.DefaultResolvers())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := sqlitesql.Config{
+		Name:        "list-orders",
+		Kind:        "sqlite-sql",
+		Source:      "orders-db",
+		Description: "List orders.",
+		Statement:   "SELECT * FROM orders WHERE customer = ? LIMIT ?;",
+		Parameters: []tools.Parameter{
+			tools.NewStringParameter("customer", "The customer."),
+			tools.NewIntParameter("limit", "Max rows."),
+		},
+	}
+	if diff := cmp.Diff(want, got.Tools["list-orders"]); diff != "" {
+		t.Fatalf("incorrect tool (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadToolsFilesWithOverlay(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"tools.yaml": `
+sources:
+	orders-db:
+		kind: sqlite
+		database: dev.db
+tools:
+	list-orders:
+		kind: sqlite-sql
+		source: orders-db
+		description: List orders.
+		statement: SELECT * FROM orders;
+	delete-orders:
+		kind: sqlite-sql
+		source: orders-db
+		description: Delete orders.
+		statement: DELETE FROM orders;
+toolsets:
+	orders:
+		- list-orders
+		- delete-orders
+`,
+		"tools.prod.yaml": `
+sources:
+	orders-db:
+		database: prod.db
+tools:
+	delete-orders: null
+toolsets:
+	orders:
+		- list-orders
+`,
+	})
+
+	// overlays in a folder are not loaded on their own, which is logged
+	var logs bytes.Buffer
+	logger, err := log.NewStdLogger(&logs, &logs, "info")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	paths, err := toolsFilePaths(util.WithLogger(ctx, logger), nil, dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff := cmp.Diff([]string{filepath.Join(dir, "tools.yaml")}, paths); diff != "" {
+		t.Fatalf("incorrect paths: diff %v", diff)
+	}
+	wantLog := fmt.Sprintf("Not loading %s on its own: it is the overlay of %s for environment %s.", filepath.Join(dir, "tools.prod.yaml"), filepath.Join(dir, "tools.yaml"), "prod")
+	if !strings.Contains(logs.String(), wantLog) {
+		t.Fatalf("expected log %q, got %q", wantLog, logs.String())
+	}
+
+	got, err := loadToolsFiles(ctx, paths, "prod", secrets.DefaultResolvers())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantSource := sqlite.Config{Name: "orders-db", Kind: "sqlite", Database: "prod.db"}
+	if diff := cmp.Diff(wantSource, got.Sources["orders-db"]); diff != "" {
+		t.Fatalf("incorrect source (-want +got):\n%s", diff)
+	}
+	if _, ok := got.Tools["delete-orders"]; ok || len(got.Tools) != 1 {
+		t.Fatalf("overlay did not remove the tool: %v", got.Tools)
+	}
+	wantToolsets := server.ToolsetConfigs{
+		"orders": tools.ToolsetConfig{Name: "orders", ToolNames: []string{"list-orders"}},
+	}
+	if diff := cmp.Diff(wantToolsets, got.Toolsets); diff != "" {
+		t.Fatalf("incorrect toolsets: diff %v", diff)
+	}
+
+	// without an environment the base file is used as is
+	got, err = loadToolsFiles(ctx, paths, "", secrets.DefaultResolvers())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(got.Tools) != 2 {
+		t.Fatalf("unexpected tools: %v", got.Tools)
+	}
+
+	_, err = loadToolsFiles(ctx, paths, "staging", secrets.DefaultResolvers())
+	if want := `no overlays found for environment "staging"`; err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
+
+func TestLoadToolsFilesWithOverlayReferences(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"tools.yaml": `
+sources:
+	orders-db:
+		kind: sqlite
+		database: ${DEV_ORDERS_DB}
+`,
+		"tools.prod.yaml": `
+sources:
+	orders-db:
+		database: ${PROD_ORDERS_DB}
+`,
+	})
+	t.Setenv("PROD_ORDERS_DB", "prod.db")
+	paths := []string{filepath.Join(dir, "tools.yaml")}
+
+	// references replaced by the overlay need not be set
+	got, err := loadToolsFiles(ctx, paths, "prod", secrets.DefaultResolvers())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantSource := sqlite.Config{Name: "orders-db", Kind: "sqlite", Database: "prod.db"}
+	if diff := cmp.Diff(wantSource, got.Sources["orders-db"]); diff != "" {
+		t.Fatalf("incorrect source (-want +got):\n%s", diff)
+	}
+
+	_, err = loadToolsFiles(ctx, paths, "", secrets.DefaultResolvers())
+	if err == nil || !strings.Contains(err.Error(), "DEV_ORDERS_DB") {
+		t.Fatalf("expected unset variable to be reported, got %v", err)
+	}
+}
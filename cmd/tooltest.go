@@ -0,0 +1,351 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// newTestCommand returns the `test` subcommand, which runs declarative tests
+// of tools against the configured sources.
+func newTestCommand(cmd *Command) *cobra.Command {
+	var junitPath string
+	c := &cobra.Command{
+		Use:   "test <test file>...",
+		Short: "Run declarative tests of tools against the configured sources",
+		Long: `Run declarative tests of tools against the configured sources.
+
+Each test file lists invocations of tools with their parameters and claims,
+and the result each is expected to return: the exact rows, a subset of the
+rows, the number of rows, or an error. Use --junit to also write the results
+as a JUnit XML report.`,
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(c *cobra.Command, args []string) error {
+			return runToolTests(c.Context(), cmd, args, junitPath)
+		},
+	}
+	c.Flags().StringVar(&junitPath, "junit", "", "File to write a JUnit XML report to.")
+	return c
+}
+
+// toolTestFile is a file of tool tests.
+type toolTestFile struct {
+	Tests []toolTest `yaml:"tests" validate:"required"`
+}
+
+// toolTest is a single invocation of a tool and its expected result.
+type toolTest struct {
+	Name   string                    `yaml:"name" validate:"required"`
+	Tool   string                    `yaml:"tool" validate:"required"`
+	Params map[string]any            `yaml:"params"`
+	Claims map[string]map[string]any `yaml:"claims"`
+	Expect toolTestExpectation       `yaml:"expect"`
+}
+
+// toolTestExpectation is the expected result of a tool test. All of the
+// expectations that are set must hold.
+type toolTestExpectation struct {
+	// Result is the exact list of rows returned.
+	Result []any `yaml:"result"`
+	// Subset lists rows that must each match a different returned row. A
+	// row matches if it has the expected value for every expected field.
+	Subset []any `yaml:"subset"`
+	// Rows is the number of rows returned.
+	Rows *int `yaml:"rows"`
+	// Error is a substring of the error the invocation fails with.
+	Error string `yaml:"error"`
+}
+
+// toolTestResult is the outcome of a tool test.
+type toolTestResult struct {
+	file     string
+	name     string
+	duration time.Duration
+	// failure is empty if the test passed.
+	failure string
+}
+
+func runToolTests(ctx context.Context, cmd *Command, testFiles []string, junitPath string) error {
+	logger, err := log.NewStdLogger(cmd.outStream, cmd.errStream, "warn")
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	ctx = util.WithLogger(ctx, logger)
+	ctx = util.WithUserAgent(ctx, cmd.cfg.Version)
+
+	paths, err := cmd.selectedToolsFiles(ctx)
+	if err != nil {
+		return err
+	}
+	env, err := cmd.selectedEnv()
+	if err != nil {
+		return err
+	}
+	toolsFile, err := loadToolsFiles(ctx, paths, env, cmd.secretResolvers)
+	if err != nil {
+		return err
+	}
+
+	files := make([]toolTestFile, len(testFiles))
+	for i, path := range testFiles {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("unable to read test file at %q: %w", path, err)
+		}
+		if err := yaml.UnmarshalContext(ctx, b, &files[i], yaml.Strict(), yaml.Validator(validator.New())); err != nil {
+			return fmt.Errorf("unable to parse test file at %q: %w", path, err)
+		}
+	}
+
+	initializer := newToolInitializer(toolsFile)
+	var results []toolTestResult
+	failures := 0
+	for i, f := range files {
+		for _, test := range f.Tests {
+			start := time.Now()
+			failure := runToolTest(ctx, initializer, toolsFile, test)
+			results = append(results, toolTestResult{file: testFiles[i], name: test.Name, duration: time.Since(start), failure: failure})
+			if failure == "" {
+				fmt.Fprintf(cmd.outStream, "PASS %s\n", test.Name)
+				continue
+			}
+			failures++
+			fmt.Fprintf(cmd.outStream, "FAIL %s\n    %s\n", test.Name, strings.ReplaceAll(failure, "\n", "\n    "))
+		}
+	}
+	fmt.Fprintf(cmd.outStream, "%d test(s), %d failure(s)\n", len(results), failures)
+
+	if junitPath != "" {
+		if err := writeJUnitReport(junitPath, results); err != nil {
+			return err
+		}
+	}
+	if failures > 0 {
+		return fmt.Errorf("%d of %d test(s) failed", failures, len(results))
+	}
+	return nil
+}
+
+// runToolTest runs a single test, returning why it failed or an empty string
+// if it passed.
+func runToolTest(ctx context.Context, initializer *toolInitializer, toolsFile ToolsFile, test toolTest) string {
+	tool, err := initializer.tool(ctx, test.Tool)
+	if err != nil {
+		return err.Error()
+	}
+	for name := range test.Claims {
+		if !hasAuthService(toolsFile, name) {
+			return fmt.Sprintf("invalid claims: no auth service named %q configured", name)
+		}
+	}
+
+	// parameters and claims are passed as JSON, like requests to the server
+	var data map[string]any
+	if err := normalizeJSON(test.Params, &data); err != nil {
+		return fmt.Sprintf("invalid params: %s", err)
+	}
+	var claimsMap map[string]map[string]any
+	if err := normalizeJSON(test.Claims, &claimsMap); err != nil {
+		return fmt.Sprintf("invalid claims: %s", err)
+	}
+
+	res, err := runTool(ctx, test.Tool, tool, data, claimsMap)
+	want := test.Expect
+	if want.Error != "" {
+		if err == nil {
+			return fmt.Sprintf("expected an error containing %q, got none", want.Error)
+		}
+		if !strings.Contains(err.Error(), want.Error) {
+			return fmt.Sprintf("expected an error containing %q, got %q", want.Error, err)
+		}
+		return ""
+	}
+	if err != nil {
+		return err.Error()
+	}
+
+	var got []any
+	if err := normalizeJSON(res, &got); err != nil {
+		return fmt.Sprintf("unable to marshal result: %s", err)
+	}
+	var failures []string
+	if want.Rows != nil && len(got) != *want.Rows {
+		failures = append(failures, fmt.Sprintf("unexpected number of rows: got %d, want %d", len(got), *want.Rows))
+	}
+	if want.Result != nil {
+		var wantResult []any
+		if err := normalizeJSON(want.Result, &wantResult); err != nil {
+			return fmt.Sprintf("invalid expected result: %s", err)
+		}
+		if !reflect.DeepEqual(got, wantResult) {
+			failures = append(failures, fmt.Sprintf("unexpected result:\n  got:  %s\n  want: %s", marshalCompact(got), marshalCompact(wantResult)))
+		}
+	}
+	if want.Subset != nil {
+		var wantSubset []any
+		if err := normalizeJSON(want.Subset, &wantSubset); err != nil {
+			return fmt.Sprintf("invalid expected subset: %s", err)
+		}
+		if missing := missingRows(got, wantSubset); len(missing) > 0 {
+			failures = append(failures, fmt.Sprintf("result has no rows matching: %s\n  got: %s", marshalCompact(missing), marshalCompact(got)))
+		}
+	}
+	return strings.Join(failures, "\n")
+}
+
+// missingRows returns the expected rows that are not matched by a different
+// row of got.
+func missingRows(got, want []any) []any {
+	used := make([]bool, len(got))
+	var missing []any
+	for _, w := range want {
+		found := false
+		for i, g := range got {
+			if !used[i] && matchesRow(g, w) {
+				used[i], found = true, true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, w)
+		}
+	}
+	return missing
+}
+
+// matchesRow reports whether got has every field of want with the same value.
+// Rows that are not objects must be equal.
+func matchesRow(got, want any) bool {
+	wantRow, ok := want.(map[string]any)
+	if !ok {
+		return reflect.DeepEqual(got, want)
+	}
+	gotRow, ok := got.(map[string]any)
+	if !ok {
+		return false
+	}
+	for k, v := range wantRow {
+		if gv, ok := gotRow[k]; !ok || !reflect.DeepEqual(gv, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeJSON converts v to the values it would be decoded to from JSON,
+// so that values read from YAML compare equal to results of tools.
+func normalizeJSON(v any, out any) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return decodeJSONNumbers(b, out)
+}
+
+func marshalCompact(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// junitTestSuites is the root element of a JUnit XML report.
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnitReport writes the results as a JUnit XML report with a test suite
+// per test file.
+func writeJUnitReport(path string, results []toolTestResult) error {
+	var report junitTestSuites
+	var suiteDurations []time.Duration
+	var total time.Duration
+	for _, r := range results {
+		if len(report.Suites) == 0 || report.Suites[len(report.Suites)-1].Name != r.file {
+			report.Suites = append(report.Suites, junitTestSuite{Name: r.file})
+			suiteDurations = append(suiteDurations, 0)
+		}
+		i := len(report.Suites) - 1
+		suite := &report.Suites[i]
+		tc := junitTestCase{Name: r.name, ClassName: r.file, Time: junitTime(r.duration)}
+		if r.failure != "" {
+			message, _, _ := strings.Cut(r.failure, "\n")
+			tc.Failure = &junitFailure{Message: message, Text: r.failure}
+			suite.Failures++
+			report.Failures++
+		}
+		suite.Cases = append(suite.Cases, tc)
+		suite.Tests++
+		report.Tests++
+		suiteDurations[i] += r.duration
+		total += r.duration
+	}
+	for i, d := range suiteDurations {
+		report.Suites[i].Time = junitTime(d)
+	}
+	report.Time = junitTime(total)
+
+	b, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal JUnit report: %w", err)
+	}
+	if err := os.WriteFile(path, append([]byte(xml.Header), append(b, '\n')...), 0o644); err != nil {
+		return fmt.Errorf("unable to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+func junitTime(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
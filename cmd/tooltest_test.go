@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// invokeToolTests runs `toolbox test` with the given arguments.
+func invokeToolTests(args []string) (string, string, error) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	c := NewCommand(WithStreams(stdout, stderr))
+	c.SilenceUsage = true
+	c.SetOut(stdout)
+	c.SetErr(stderr)
+	c.SetArgs(append([]string{"test"}, args...))
+	err := c.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestToolTests(t *testing.T) {
+	toolsPath := writeInvokeToolsFile(t)
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"pass.yaml": `
+tests:
+	- name: exact result
+		tool: echo
+		params:
+			name: alice
+			count: 2
+			ratio: 0.5
+		expect:
+			result:
+				- name: alice
+					count: 2
+					ratio: 0.5
+	- name: subset and rows
+		tool: echo
+		params: {name: bob, count: 3, ratio: 1.5}
+		expect:
+			rows: 1
+			subset:
+				- name: bob
+	- name: claims
+		tool: whoami
+		claims:
+			my-google:
+				email: alice@example.com
+		expect:
+			result:
+				- email: alice@example.com
+	- name: expected error
+		tool: whoami
+		expect:
+			error: requires claims
+`,
+		"fail.yaml": `
+tests:
+	- name: wrong rows
+		tool: echo
+		params: {name: bob, count: 3, ratio: 1.5}
+		expect:
+			rows: 2
+			subset:
+				- name: alice
+	- name: unexpected error
+		tool: echo
+		params: {name: bob}
+		expect:
+			rows: 1
+`,
+	})
+
+	stdout, stderr, err := invokeToolTests([]string{filepath.Join(dir, "pass.yaml"), "--tools-file", toolsPath})
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s%s", err, stdout, stderr)
+	}
+	want := "PASS exact result\nPASS subset and rows\nPASS claims\nPASS expected error\n4 test(s), 0 failure(s)\n"
+	if stdout != want {
+		t.Fatalf("unexpected output: got %q, want %q", stdout, want)
+	}
+
+	junitPath := filepath.Join(dir, "report.xml")
+	failPath := filepath.Join(dir, "fail.yaml")
+	stdout, _, err = invokeToolTests([]string{filepath.Join(dir, "pass.yaml"), failPath, "--tools-file", toolsPath, "--junit", junitPath})
+	if err == nil || err.Error() != "2 of 6 test(s) failed" {
+		t.Fatalf("unexpected error: got %v, want %q", err, "2 of 6 test(s) failed")
+	}
+	for _, want := range []string{
+		"FAIL wrong rows\n    unexpected number of rows: got 1, want 2\n    result has no rows matching: [{\"name\":\"alice\"}]\n",
+		"FAIL unexpected error\n    provided parameters were invalid: parameter \"count\" is required; parameter \"ratio\" is required\n",
+		"6 test(s), 2 failure(s)\n",
+	} {
+		if !strings.Contains(stdout, want) {
+			t.Fatalf("output does not contain %q:\n%s", want, stdout)
+		}
+	}
+
+	b, err := os.ReadFile(junitPath)
+	if err != nil {
+		t.Fatalf("unable to read JUnit report: %s", err)
+	}
+	var report junitTestSuites
+	if err := xml.Unmarshal(b, &report); err != nil {
+		t.Fatalf("unable to parse JUnit report: %s", err)
+	}
+	type suiteSummary struct {
+		Name            string
+		Tests, Failures int
+		FailedCases     []string
+	}
+	var got []suiteSummary
+	for _, s := range report.Suites {
+		summary := suiteSummary{Name: s.Name, Tests: s.Tests, Failures: s.Failures}
+		for _, c := range s.Cases {
+			if c.Failure != nil {
+				summary.FailedCases = append(summary.FailedCases, c.Name+": "+c.Failure.Message)
+			}
+		}
+		got = append(got, summary)
+	}
+	wantSuites := []suiteSummary{
+		{Name: filepath.Join(dir, "pass.yaml"), Tests: 4},
+		{Name: failPath, Tests: 2, Failures: 2, FailedCases: []string{
+			"wrong rows: unexpected number of rows: got 1, want 2",
+			`unexpected error: provided parameters were invalid: parameter "count" is required; parameter "ratio" is required`,
+		}},
+	}
+	if diff := cmp.Diff(wantSuites, got); diff != "" {
+		t.Fatalf("incorrect JUnit report (-want +got):\n%s", diff)
+	}
+	if report.Tests != 6 || report.Failures != 2 {
+		t.Fatalf("incorrect JUnit totals: got %d tests and %d failures", report.Tests, report.Failures)
+	}
+}
+
+func TestFailToolTestsFile(t *testing.T) {
+	toolsPath := writeInvokeToolsFile(t)
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"invalid.yaml": `
+tests:
+	- name: missing tool
+		expect:
+			rows: 1
+`,
+	})
+	_, _, err := invokeToolTests([]string{filepath.Join(dir, "invalid.yaml"), "--tools-file", toolsPath})
+	if err == nil || !strings.Contains(err.Error(), "unable to parse test file") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,512 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+	"github.com/googleapis/genai-toolbox/internal/auth"
+	"github.com/googleapis/genai-toolbox/internal/log"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
+	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/spf13/cobra"
+)
+
+// newValidateCommand returns the `validate` subcommand, which checks the tools
+// configuration without connecting to any sources.
+func newValidateCommand(cmd *Command) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the tools configuration without connecting to any sources",
+		Long: `Check the tools configuration without connecting to any sources.
+
+All problems are reported at once, each prefixed with the file and line where
+it was found. Files are read as when the server starts, with overlays applied
+and environment variables expanded, so unset variables are reported. Secrets
+are not read, so that no credentials are needed.`,
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE:         func(c *cobra.Command, _ []string) error { return validate(c.Context(), cmd) },
+	}
+}
+
+func validate(ctx context.Context, cmd *Command) error {
+	logger, err := log.NewStdLogger(cmd.outStream, cmd.errStream, "warn")
+	if err != nil {
+		return fmt.Errorf("unable to initialize logger: %w", err)
+	}
+	ctx = util.WithLogger(ctx, logger)
+
+	paths, err := cmd.selectedToolsFiles(ctx)
+	if err != nil {
+		return err
+	}
+	env, err := cmd.selectedEnv()
+	if err != nil {
+		return err
+	}
+	v := newConfigValidator()
+	v.addFiles(ctx, paths, env, cmd.secretResolvers.Offline())
+	v.checkReferences()
+
+	if len(v.problems) > 0 {
+		for _, p := range v.sortedProblems() {
+			fmt.Fprintln(cmd.errStream, p)
+		}
+		return fmt.Errorf("found %d problem(s) in the tools configuration", len(v.problems))
+	}
+	fmt.Fprintf(cmd.outStream, "Tools configuration is valid: %d source(s), %d auth service(s), %d tool(s), %d toolset(s).\n",
+		len(v.sources), len(v.authServices), len(v.tools), len(v.toolsets))
+	return nil
+}
+
+// location is a position in a tools file. A zero line refers to the whole file.
+type location struct {
+	path string
+	line int
+}
+
+func (l location) String() string {
+	if l.line == 0 {
+		return l.path
+	}
+	return fmt.Sprintf("%s:%d", l.path, l.line)
+}
+
+type configProblem struct {
+	loc location
+	msg string
+}
+
+func (p configProblem) String() string {
+	return fmt.Sprintf("%s: %s", p.loc, p.msg)
+}
+
+// definition is a resource decoded from a tools file along with its node,
+// which is used to locate the fields it refers to other resources by.
+type definition[T any] struct {
+	config T
+	loc    location
+	node   *ast.MappingValueNode
+}
+
+// field returns the location of the named field, or of the resource itself
+// if it is not set.
+func (d definition[T]) field(name string) location {
+	entries, _ := mappingEntries(d.node.Value)
+	for _, e := range entries {
+		if e.Key.GetToken().Value == name {
+			return location{path: d.loc.path, line: nodeLine(e.Key)}
+		}
+	}
+	return d.loc
+}
+
+// parameter returns the location of the named parameter, or of the resource
+// itself if it can't be found.
+func (d definition[T]) parameter(name string) location {
+	entries, _ := mappingEntries(d.node.Value)
+	for _, e := range entries {
+		seq, ok := e.Value.(*ast.SequenceNode)
+		if !ok {
+			continue
+		}
+		for _, item := range seq.Values {
+			fields, _ := mappingEntries(item)
+			for _, f := range fields {
+				if f.Key.GetToken().Value == "name" && f.Value.GetToken() != nil && f.Value.GetToken().Value == name {
+					return location{path: d.loc.path, line: nodeLine(item)}
+				}
+			}
+		}
+	}
+	return d.loc
+}
+
+// configValidator collects every problem found in a set of tools files.
+type configValidator struct {
+	sources      map[string]definition[sources.SourceConfig]
+	authServices map[string]definition[auth.AuthServiceConfig]
+	tools        map[string]definition[tools.ToolConfig]
+	toolsets     map[string]definition[tools.ToolsetConfig]
+	templates    map[string]definition[map[string]any]
+	// fileOrder is used to report problems in the order files were given.
+	fileOrder map[string]int
+	problems  []configProblem
+}
+
+func newConfigValidator() *configValidator {
+	return &configValidator{
+		sources:      make(map[string]definition[sources.SourceConfig]),
+		authServices: make(map[string]definition[auth.AuthServiceConfig]),
+		tools:        make(map[string]definition[tools.ToolConfig]),
+		toolsets:     make(map[string]definition[tools.ToolsetConfig]),
+		templates:    make(map[string]definition[map[string]any]),
+		fileOrder:    make(map[string]int),
+	}
+}
+
+func (v *configValidator) report(loc location, format string, a ...any) {
+	v.problems = append(v.problems, configProblem{loc: loc, msg: fmt.Sprintf(format, a...)})
+}
+
+func (v *configValidator) sortedProblems() []configProblem {
+	problems := slices.Clone(v.problems)
+	slices.SortStableFunc(problems, func(a, b configProblem) int {
+		if c := v.fileOrder[a.loc.path] - v.fileOrder[b.loc.path]; c != 0 {
+			return c
+		}
+		return a.loc.line - b.loc.line
+	})
+	return problems
+}
+
+// fileEntry is a resource in a tools file that is yet to be decoded.
+type fileEntry struct {
+	path    string
+	section string
+	// node is decoded, while pos locates the resource as written in its
+	// tools file, or in the overlay if the overlay patches it.
+	node *ast.MappingValueNode
+	pos  *ast.MappingValueNode
+	dec  *yaml.Decoder
+}
+
+// addFiles decodes each resource on its own, so that a problem in one resource
+// doesn't hide problems in the others. Templates are decoded first, since
+// tools in any file may extend them. Files are read like loadToolsFiles does,
+// applying the overlay of each file for env if it is set.
+func (v *configValidator) addFiles(ctx context.Context, paths []string, env string, resolvers secrets.Resolvers) {
+	var entries []fileEntry
+	overlays := 0
+	for _, path := range paths {
+		fileEntries, overlay := v.parseFile(ctx, path, env, resolvers)
+		if overlay != "" {
+			overlays++
+		}
+		entries = append(entries, fileEntries...)
+	}
+	if env != "" && overlays == 0 && len(paths) > 0 {
+		v.report(location{path: paths[0]}, "no overlays found for environment %q", env)
+	}
+
+	templates := make(server.ToolTemplates)
+	for _, e := range entries {
+		if e.section != "templates" {
+			continue
+		}
+		name := e.node.Key.GetToken().Value
+		loc := e.location()
+		var c server.ToolTemplates
+		if err := e.dec.DecodeFromNodeContext(ctx, e.node, &c); err != nil {
+			v.report(loc, "template %q: %s", name, firstLine(err))
+			continue
+		}
+		if define(v, v.templates, "template", name, definition[map[string]any]{config: c[name], loc: loc, node: e.pos}) {
+			templates[name] = c[name]
+		}
+	}
+	ctx = server.WithToolTemplates(ctx, templates)
+
+	for _, e := range entries {
+		v.addEntry(ctx, e)
+	}
+}
+
+// parseFile returns the resources defined in a tools file, along with the
+// path of the overlay applied to it, if any.
+func (v *configValidator) parseFile(ctx context.Context, path, env string, resolvers secrets.Resolvers) ([]fileEntry, string) {
+	v.addFileOrder(path)
+	fileLoc := location{path: path}
+
+	contents, overlay, err := readToolsFile(ctx, path, env, resolvers)
+	if err != nil {
+		v.report(fileLoc, "%s", err)
+		return nil, ""
+	}
+	f, err := parser.ParseBytes(contents, 0)
+	if err != nil {
+		v.report(fileLoc, "unable to parse tools file: %s", yaml.FormatError(err, false, false))
+		return nil, overlay
+	}
+
+	// locate resources in the files as written, since the contents are
+	// re-encoded when an overlay is applied
+	positions := resourcePositions(path)
+	if overlay != "" {
+		v.addFileOrder(overlay)
+		for key, pos := range resourcePositions(overlay) {
+			positions[key] = pos
+		}
+	}
+
+	var out []fileEntry
+	dec := yaml.NewDecoder(bytes.NewReader(nil), yaml.Strict())
+	for _, doc := range f.Docs {
+		// register anchors so that aliases can be resolved per resource
+		_ = dec.DecodeFromNodeContext(ctx, doc.Body, new(any))
+
+		sections, ok := mappingEntries(doc.Body)
+		if !ok {
+			v.report(location{path: path, line: nodeLine(doc.Body)}, "tools file must be a mapping")
+			continue
+		}
+		for _, section := range sections {
+			key := section.Key.GetToken().Value
+			sectionPos, ok := positions[resourceKey{section: key}]
+			if !ok {
+				sectionPos = filePosition{path: path}
+			}
+			if !slices.Contains([]string{"sources", "authServices", "authSources", "templates", "tools", "toolsets"}, key) {
+				v.report(sectionPos.location(), "unknown field %q", key)
+				continue
+			}
+			entries, ok := mappingEntries(section.Value)
+			if !ok {
+				v.report(sectionPos.location(), "%q must be a mapping", key)
+				continue
+			}
+			for _, entry := range entries {
+				e := fileEntry{path: path, section: key, node: entry, pos: entry, dec: dec}
+				if pos, ok := positions[resourceKey{section: key, name: entry.Key.GetToken().Value}]; ok {
+					e.path, e.pos = pos.path, pos.node
+				}
+				out = append(out, e)
+			}
+		}
+	}
+	return out, overlay
+}
+
+func (v *configValidator) addFileOrder(path string) {
+	if _, ok := v.fileOrder[path]; !ok {
+		v.fileOrder[path] = len(v.fileOrder)
+	}
+}
+
+func (e fileEntry) location() location {
+	return location{path: e.path, line: nodeLine(e.pos.Key)}
+}
+
+// resourceKey identifies a section of a tools file, or a resource within it.
+type resourceKey struct {
+	section, name string
+}
+
+// filePosition is the node of a section or resource in a tools file.
+type filePosition struct {
+	path string
+	node *ast.MappingValueNode
+}
+
+func (p filePosition) location() location {
+	if p.node == nil {
+		return location{path: p.path}
+	}
+	return location{path: p.path, line: nodeLine(p.node.Key)}
+}
+
+// resourcePositions returns the positions of the sections and resources of a
+// tools file as written. Files that can't be parsed have no positions.
+func resourcePositions(path string) map[resourceKey]filePosition {
+	positions := make(map[resourceKey]filePosition)
+	f, err := parser.ParseFile(path, 0)
+	if err != nil {
+		return positions
+	}
+	for _, doc := range f.Docs {
+		sections, _ := mappingEntries(doc.Body)
+		for _, section := range sections {
+			key := section.Key.GetToken().Value
+			positions[resourceKey{section: key}] = filePosition{path: path, node: section}
+			entries, _ := mappingEntries(section.Value)
+			for _, entry := range entries {
+				positions[resourceKey{section: key, name: entry.Key.GetToken().Value}] = filePosition{path: path, node: entry}
+			}
+		}
+	}
+	return positions
+}
+
+func (v *configValidator) addEntry(ctx context.Context, e fileEntry) {
+	dec, entry := e.dec, e.node
+	name := entry.Key.GetToken().Value
+	loc := e.location()
+	switch e.section {
+	case "sources":
+		var c server.SourceConfigs
+		if err := dec.DecodeFromNodeContext(ctx, entry, &c); err != nil {
+			v.report(loc, "source %q: %s", name, firstLine(err))
+			return
+		}
+		define(v, v.sources, "source", name, definition[sources.SourceConfig]{config: c[name], loc: loc, node: e.pos})
+	case "authServices", "authSources":
+		// deprecated authSources share a namespace with authServices
+		var c server.AuthServiceConfigs
+		if err := dec.DecodeFromNodeContext(ctx, entry, &c); err != nil {
+			v.report(loc, "auth service %q: %s", name, firstLine(err))
+			return
+		}
+		define(v, v.authServices, "authService", name, definition[auth.AuthServiceConfig]{config: c[name], loc: loc, node: e.pos})
+	case "tools":
+		var c server.ToolConfigs
+		if err := dec.DecodeFromNodeContext(ctx, entry, &c); err != nil {
+			v.report(loc, "tool %q: %s", name, firstLine(err))
+			return
+		}
+		define(v, v.tools, "tool", name, definition[tools.ToolConfig]{config: c[name], loc: loc, node: e.pos})
+	case "toolsets":
+		var c server.ToolsetConfigs
+		if err := dec.DecodeFromNodeContext(ctx, entry, &c); err != nil {
+			v.report(loc, "toolset %q: %s", name, firstLine(err))
+			return
+		}
+		define(v, v.toolsets, "toolset", name, definition[tools.ToolsetConfig]{config: c[name], loc: loc, node: e.pos})
+	}
+}
+
+// define records a resource, reporting it and returning false if the name is
+// already taken.
+func define[T any](v *configValidator, defs map[string]definition[T], resource, name string, d definition[T]) bool {
+	if prev, ok := defs[name]; ok {
+		v.report(d.loc, "duplicate %s %q: already defined at %s", resource, name, prev.loc)
+		return false
+	}
+	defs[name] = d
+	return true
+}
+
+// checkReferences reports tools that refer to missing or incompatible sources
+// or to missing auth services, and toolsets that refer to missing tools.
+func (v *configValidator) checkReferences() {
+	for name, d := range v.tools {
+		r, ok := d.config.(tools.ReferencingToolConfig)
+		if !ok {
+			continue
+		}
+		refs := r.ToolConfigReferences()
+		if refs.Source != "" {
+			src, ok := v.sources[refs.Source]
+			switch {
+			case !ok:
+				v.report(d.field("source"), "tool %q: no source named %q configured", name, refs.Source)
+			case len(refs.CompatibleSourceKinds) > 0 && !slices.Contains(refs.CompatibleSourceKinds, src.config.SourceConfigKind()):
+				v.report(d.field("source"), "tool %q: source %q has kind %q, but %q tools require one of: %s",
+					name, refs.Source, src.config.SourceConfigKind(), d.config.ToolConfigKind(), strings.Join(refs.CompatibleSourceKinds, ", "))
+			}
+		}
+		for _, a := range refs.AuthRequired {
+			if _, ok := v.authServices[a]; !ok {
+				v.report(d.field("authRequired"), "tool %q: no auth service named %q configured", name, a)
+			}
+		}
+		for _, a := range refs.AuthServices {
+			if _, ok := v.authServices[a]; !ok {
+				v.report(d.loc, "tool %q: no auth service named %q configured", name, a)
+			}
+		}
+		for _, p := range refs.Parameters {
+			for _, a := range p.GetAuthServices() {
+				if _, ok := v.authServices[a.Name]; !ok {
+					v.report(d.parameter(p.GetName()), "tool %q: parameter %q: no auth service named %q configured", name, p.GetName(), a.Name)
+				}
+			}
+		}
+	}
+
+	toolsets := make(map[string]tools.ToolsetConfig, len(v.toolsets))
+	for name, d := range v.toolsets {
+		toolsets[name] = d.config
+	}
+	toolConfigs := make(map[string]tools.ToolConfig, len(v.tools))
+	for name, d := range v.tools {
+		toolConfigs[name] = d.config
+	}
+	for name, d := range v.toolsets {
+		seq, _ := d.node.Value.(*ast.SequenceNode)
+		itemLoc := func(i int) location {
+			loc := d.loc
+			if seq != nil && i < len(seq.Values) {
+				loc.line = nodeLine(seq.Values[i])
+			}
+			return loc
+		}
+		for i, toolName := range d.config.ToolNames {
+			if _, ok := v.tools[toolName]; !ok {
+				v.report(itemLoc(i), "toolset %q: no tool named %q configured", name, toolName)
+			}
+		}
+		for i, sel := range d.config.Selectors {
+			switch {
+			case sel.Exclude:
+			case sel.Tool != "" && !strings.ContainsAny(sel.Tool, "*?["):
+				if _, ok := v.tools[sel.Tool]; !ok {
+					v.report(itemLoc(i), "toolset %q: no tool named %q configured", name, sel.Tool)
+				}
+			case sel.Toolset != "":
+				if _, ok := v.toolsets[sel.Toolset]; !ok {
+					v.report(itemLoc(i), "toolset %q: no toolset named %q configured", name, sel.Toolset)
+				}
+			}
+		}
+		if _, err := tools.ResolveToolset(name, toolsets, toolConfigs); errors.Is(err, tools.ErrToolsetCycle) {
+			v.report(d.loc, "toolset %q: %s", name, err)
+		}
+	}
+}
+
+// mappingEntries returns the entries of a mapping node. A missing or null
+// node is an empty mapping.
+func mappingEntries(n ast.Node) ([]*ast.MappingValueNode, bool) {
+	switch n := n.(type) {
+	case nil, *ast.NullNode:
+		return nil, true
+	case *ast.MappingNode:
+		return n.Values, true
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{n}, true
+	case *ast.AnchorNode:
+		return mappingEntries(n.Value)
+	case *ast.TagNode:
+		return mappingEntries(n.Value)
+	default:
+		return nil, false
+	}
+}
+
+// firstLine drops the source snippet included in decoding errors, since it
+// shows the resource as re-encoded rather than as written in the file.
+func firstLine(err error) string {
+	msg, _, _ := strings.Cut(err.Error(), "\n")
+	return msg
+}
+
+func nodeLine(n ast.Node) int {
+	if n == nil || n.GetToken() == nil {
+		return 0
+	}
+	return n.GetToken().Position.Line
+}
@@ -0,0 +1,370 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// invokeValidate runs `toolbox validate` with the given flags.
+func invokeValidate(args []string) (string, string, error) {
+	stdout, stderr := new(bytes.Buffer), new(bytes.Buffer)
+	c := NewCommand(WithStreams(stdout, stderr))
+	c.SilenceUsage = true
+	c.SetOut(stdout)
+	c.SetErr(stderr)
+	c.SetArgs(append([]string{"validate"}, args...))
+	err := c.Execute()
+	return stdout.String(), stderr.String(), err
+}
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"orders.yaml": ordersToolsFile,
+		"users.yaml":  usersToolsFile,
+	})
+
+	stdout, stderr, err := invokeValidate([]string{"--tools-folder", dir})
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stderr)
+	}
+	want := "Tools configuration is valid: 2 source(s), 0 auth service(s), 2 tool(s), 1 toolset(s)."
+	if !strings.Contains(stdout, want) {
+		t.Fatalf("unexpected output: got %q, want substring %q", stdout, want)
+	}
+}
+
+func TestFailValidateReferences(t *testing.T) {
+	// environment variables are expanded as when the server starts
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"tools.yaml": `
+sources:
+	my-pg-source:
+		kind: postgres
+		host: ${VALIDATE_TEST_UNSET_HOST}
+		port: ${VALIDATE_TEST_UNSET_PORT:-5432}
+		database: my_db
+		user: my_user
+		password: my_password
+`,
+	})
+	path := filepath.Join(dir, "tools.yaml")
+
+	_, stderr, err := invokeValidate([]string{"--tools-file", path})
+	if err == nil {
+		t.Fatalf("expect validation to fail")
+	}
+	want := path + `: unable to parse tool file at "` + path + `": unable to resolve references: environment variable "VALIDATE_TEST_UNSET_HOST" is not set`
+	if got := strings.TrimSpace(stderr); got != want {
+		t.Fatalf("unexpected problems: got %q, want %q", got, want)
+	}
+}
+
+func TestValidateOffline(t *testing.T) {
+	// secrets are not read, so no credentials are needed
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"tools.yaml": `
+sources:
+	my-pg-source:
+		kind: postgres
+		host: ${VALIDATE_TEST_UNSET_HOST:-localhost}
+		port: 5432
+		database: my_db
+		user: my_user
+		password: ${secretmanager:projects/my-project/secrets/db-password}
+`,
+	})
+
+	stdout, stderr, err := invokeValidate([]string{"--tools-file", filepath.Join(dir, "tools.yaml")})
+	if err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stderr)
+	}
+	want := "Tools configuration is valid: 1 source(s), 0 auth service(s), 0 tool(s), 0 toolset(s)."
+	if !strings.Contains(stdout, want) {
+		t.Fatalf("unexpected output: got %q, want substring %q", stdout, want)
+	}
+}
+
+func TestFailValidate(t *testing.T) {
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"a.yaml": `
+sources:
+	my-sqlite:
+		kind: sqlite
+		database: my.db
+authServices:
+	my-google:
+		kind: google
+		clientId: my-client-id
+tools:
+	wrong-source-kind:
+		kind: postgres-sql
+		source: my-sqlite
+		description: Wrong source kind.
+		statement: SELECT 1;
+	missing-references:
+		kind: sqlite-sql
+		source: missing-source
+		description: Missing references.
+		statement: SELECT 1;
+		authRequired:
+			- missing-auth
+		parameters:
+			- name: user
+				type: string
+				description: The user.
+				authServices:
+					- name: missing-param-auth
+						field: sub
+	invalid-kind:
+		kind: not-a-kind
+toolsets:
+	my-toolset:
+		- wrong-source-kind
+		- missing-tool
+`,
+		"b.yaml": `
+sources:
+	my-sqlite:
+		kind: sqlite
+		database: other.db
+	unknown-field:
+		kind: sqlite
+		database: other.db
+		unknownField: true
+`,
+	})
+	a, b := filepath.Join(dir, "a.yaml"), filepath.Join(dir, "b.yaml")
+
+	_, stderr, err := invokeValidate([]string{"--tools-file", a, "--tools-file", b})
+	if err == nil {
+		t.Fatalf("expect validation to fail")
+	}
+	if want := "found 8 problem(s)"; !strings.Contains(err.Error(), want) {
+		t.Fatalf("unexpected error: got %q, want substring %q\n%s", err, want, stderr)
+	}
+	wantLines := []string{
+		a + `:13: tool "wrong-source-kind": source "my-sqlite" has kind "sqlite", but "postgres-sql" tools require one of: alloydb-postgres, cloud-sql-postgres, postgres`,
+		a + `:18: tool "missing-references": no source named "missing-source" configured`,
+		a + `:21: tool "missing-references": no auth service named "missing-auth" configured`,
+		a + `:24: tool "missing-references": parameter "user": no auth service named "missing-param-auth" configured`,
+		a + `:30: tool "invalid-kind": "not-a-kind" is not a valid kind of tool`,
+		a + `:35: toolset "my-toolset": no tool named "missing-tool" configured`,
+		b + `:3: duplicate source "my-sqlite": already defined at ` + a + `:3`,
+		b + `:6: source "unknown-field": unable to parse as "sqlite": [3:1] unknown field "unknownField"`,
+	}
+	got := strings.Split(strings.TrimSpace(stderr), "\n")
+	if len(got) != len(wantLines) {
+		t.Fatalf("unexpected number of problems: got %d, want %d\n%s", len(got), len(wantLines), stderr)
+	}
+	for i, want := range wantLines {
+		if !strings.HasPrefix(got[i], want) {
+			t.Errorf("unexpected problem %d: got %q, want prefix %q", i, got[i], want)
+		}
+	}
+}
+
+func TestValidateTemplates(t *testing.T) {
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"base.yaml": `
+sources:
+	orders-db:
+		kind: sqlite
+		database: orders.db
+templates:
+	orders-tool:
+		kind: sqlite-sql
+		source: orders-db
+`,
+		"orders.yaml": `
+tools:
+	list-orders:
+		extends: orders-tool
+		description: List orders.
+		statement: SELECT * FROM orders;
+	list-returns:
+		extends: missing-tool
+		description: List returns.
+		statement: SELECT * FROM returns;
+`,
+	})
+	base, orders := filepath.Join(dir, "base.yaml"), filepath.Join(dir, "orders.yaml")
+
+	_, stderr, err := invokeValidate([]string{"--tools-file", base, "--tools-file", orders})
+	if err == nil {
+		t.Fatalf("expect validation to fail")
+	}
+	want := orders + `:7: tool "list-returns": unable to apply templates to "list-returns": no template named "missing-tool" configured`
+	if got := strings.TrimSpace(stderr); got != want {
+		t.Fatalf("unexpected problems: got %q, want %q", got, want)
+	}
+}
+
+func TestValidateToolsets(t *testing.T) {
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"tools.yaml": `
+sources:
+	orders-db:
+		kind: sqlite
+		database: orders.db
+tools:
+	orders-list:
+		kind: sqlite-sql
+		source: orders-db
+		description: List orders.
+		statement: SELECT * FROM orders;
+toolsets:
+	orders:
+		- orders-*
+		- missing-tool
+	reporting:
+		- toolset: orders
+		- toolset: missing-toolset
+	a:
+		- toolset: b
+	b:
+		- toolset: a
+		- exclude:
+				kind: sqlite-sql
+`,
+	})
+	path := filepath.Join(dir, "tools.yaml")
+
+	_, stderr, err := invokeValidate([]string{"--tools-file", path})
+	if err == nil {
+		t.Fatalf("expect validation to fail")
+	}
+	wantLines := []string{
+		path + `:15: toolset "orders": no tool named "missing-tool" configured`,
+		path + `:18: toolset "reporting": no toolset named "missing-toolset" configured`,
+		path + `:19: toolset "a": toolsets include each other in a cycle: a -> b -> a`,
+		path + `:21: toolset "b": toolsets include each other in a cycle: b -> a -> b`,
+	}
+	if diff := cmp.Diff(wantLines, strings.Split(strings.TrimSpace(stderr), "\n")); diff != "" {
+		t.Fatalf("unexpected problems (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateOverlays(t *testing.T) {
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"tools.yaml": `
+sources:
+	orders-db:
+		kind: sqlite
+		database: orders.db
+tools:
+	list-orders:
+		kind: sqlite-sql
+		source: orders-db
+		description: List orders.
+		statement: SELECT * FROM orders;
+toolsets:
+	orders:
+		- list-orders
+`,
+		"tools.prod.yaml": `
+tools:
+	list-orders:
+		source: prod-db
+`,
+	})
+	path := filepath.Join(dir, "tools.yaml")
+
+	if _, stderr, err := invokeValidate([]string{"--tools-file", path}); err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stderr)
+	}
+	_, stderr, err := invokeValidate([]string{"--tools-file", path, "--env", "prod"})
+	if err == nil {
+		t.Fatalf("expect validation to fail")
+	}
+	want := filepath.Join(dir, "tools.prod.yaml") + `:4: tool "list-orders": no source named "prod-db" configured`
+	if got := strings.TrimSpace(stderr); got != want {
+		t.Fatalf("unexpected problems: got %q, want %q", got, want)
+	}
+}
+
+func TestValidateOverlayReferences(t *testing.T) {
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"tools.yaml": `
+sources:
+	orders-db:
+		kind: sqlite
+		database: ${VALIDATE_TEST_UNSET_DEV_DB}
+`,
+		"tools.prod.yaml": `
+sources:
+	orders-db:
+		database: ${VALIDATE_TEST_PROD_DB}
+`,
+	})
+	t.Setenv("VALIDATE_TEST_PROD_DB", "prod.db")
+	path := filepath.Join(dir, "tools.yaml")
+
+	// references replaced by the overlay need not be set, like when the
+	// server starts
+	if _, stderr, err := invokeValidate([]string{"--tools-file", path, "--env", "prod"}); err != nil {
+		t.Fatalf("unexpected error: %s\n%s", err, stderr)
+	}
+	if _, _, err := invokeValidate([]string{"--tools-file", path}); err == nil {
+		t.Fatalf("expect validation to fail without the overlay")
+	}
+}
+
+func TestFailValidateSessionSettings(t *testing.T) {
+	dir := t.TempDir()
+	writeToolsFiles(t, dir, map[string]string{
+		"tools.yaml": `
+sources:
+	my-pg-source:
+		kind: postgres
+		host: 127.0.0.1
+		port: 5432
+		database: my_db
+		user: my_user
+		password: my_password
+tools:
+	list-orders:
+		kind: postgres-sql
+		source: my-pg-source
+		description: List the orders of the user.
+		statement: SELECT * FROM orders;
+		sessionSettings:
+			app.user_email: my-google.email
+`,
+	})
+	path := filepath.Join(dir, "tools.yaml")
+
+	_, stderr, err := invokeValidate([]string{"--tools-file", path})
+	if err == nil {
+		t.Fatalf("expect validation to fail")
+	}
+	want := path + `:11: tool "list-orders": no auth service named "my-google" configured`
+	if got := strings.TrimSpace(stderr); got != want {
+		t.Fatalf("unexpected problems: got %q, want %q", got, want)
+	}
+}
@@ -16,7 +16,13 @@ package auth
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
 )
 
 // AuthServiceConfig is the interface for configuring authentication services.
@@ -31,3 +37,43 @@ type AuthService interface {
 	GetName() string
 	GetClaimsFromHeader(context.Context, http.Header) (map[string]any, error)
 }
+
+// AuthServiceConfigFactory decodes the configuration of the auth service with the given name.
+type AuthServiceConfigFactory func(ctx context.Context, name string, decoder *yaml.Decoder) (AuthServiceConfig, error)
+
+var authServiceRegistry = make(map[string]AuthServiceConfigFactory)
+
+// Register makes a kind of auth service available in tools files. It is intended to
+// be called from the init function of the package implementing the kind, and
+// panics if the kind is already registered.
+func Register(kind string, factory AuthServiceConfigFactory) {
+	if _, exists := authServiceRegistry[kind]; exists {
+		panic(fmt.Sprintf("auth: kind %q is already registered", kind))
+	}
+	authServiceRegistry[kind] = factory
+}
+
+// Kinds returns the registered kinds of auth services in sorted order.
+func Kinds() []string {
+	return slices.Sorted(maps.Keys(authServiceRegistry))
+}
+
+// DecodeConfig decodes the configuration of a auth service with the factory
+// registered for its kind.
+func DecodeConfig(ctx context.Context, kind, name string, decoder *yaml.Decoder) (AuthServiceConfig, error) {
+	factory, ok := authServiceRegistry[kind]
+	if !ok {
+		return nil, fmt.Errorf("%q is not a valid kind of auth service, must be one of: %s", kind, strings.Join(Kinds(), ", "))
+	}
+	cfg, err := factory(ctx, name, decoder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse as %q: %w", kind, err)
+	}
+	return cfg, nil
+}
+
+// DefaultConfig returns the configuration of a auth service of the given kind with
+// only its defaults set, so that its fields can be inspected.
+func DefaultConfig(kind string) (AuthServiceConfig, error) {
+	return DecodeConfig(context.Background(), kind, "", yaml.NewDecoder(strings.NewReader("{}")))
+}
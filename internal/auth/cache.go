@@ -0,0 +1,181 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+const (
+	// DefaultClaimsCacheSize is the number of verified tokens kept per auth service.
+	DefaultClaimsCacheSize = 1024
+
+	meterName                = "github.com/googleapis/genai-toolbox/internal/auth"
+	claimsCacheHitCountName  = "toolbox.auth.claims_cache.hit.count"
+	claimsCacheMissCountName = "toolbox.auth.claims_cache.miss.count"
+)
+
+// claimsCacheEntry is a single cached set of claims.
+type claimsCacheEntry struct {
+	key       string
+	claims    map[string]any
+	expiresAt time.Time
+}
+
+// ClaimsCache is a bounded, concurrency-safe LRU cache of verified token
+// claims. Entries are keyed by a hash of the raw token and are never returned
+// after the token's `exp` claim has passed.
+type ClaimsCache struct {
+	name     string
+	capacity int
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List
+
+	hits   metric.Int64Counter
+	misses metric.Int64Counter
+}
+
+// NewClaimsCache returns a ClaimsCache that holds at most capacity entries.
+// The name is attached to the hit and miss metrics.
+func NewClaimsCache(name string, capacity int) (*ClaimsCache, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("claims cache capacity must be positive, got %d", capacity)
+	}
+	meter := otel.Meter(meterName)
+	hits, err := meter.Int64Counter(
+		claimsCacheHitCountName,
+		metric.WithDescription("Number of token verifications served from the claims cache."),
+		metric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", claimsCacheHitCountName, err)
+	}
+	misses, err := meter.Int64Counter(
+		claimsCacheMissCountName,
+		metric.WithDescription("Number of token verifications not found in the claims cache."),
+		metric.WithUnit("{call}"),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create %s metric: %w", claimsCacheMissCountName, err)
+	}
+	return &ClaimsCache{
+		name:     name,
+		capacity: capacity,
+		now:      time.Now,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		hits:     hits,
+		misses:   misses,
+	}, nil
+}
+
+// Get returns the cached claims for the token, if present and not expired.
+func (c *ClaimsCache) Get(ctx context.Context, token string) (map[string]any, bool) {
+	key := hashToken(token)
+
+	c.mu.Lock()
+	claims, ok := c.get(key)
+	c.mu.Unlock()
+
+	counter := c.misses
+	if ok {
+		counter = c.hits
+	}
+	counter.Add(ctx, 1, metric.WithAttributes(attribute.String("toolbox.name", c.name)))
+	return claims, ok
+}
+
+func (c *ClaimsCache) get(key string) (map[string]any, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := e.Value.(*claimsCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.order.Remove(e)
+		delete(c.entries, key)
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return entry.claims, true
+}
+
+// Add stores the claims for the token until the token's `exp` claim. Claims
+// without a usable `exp` are not cached.
+func (c *ClaimsCache) Add(token string, claims map[string]any) {
+	expiresAt, ok := expiryFromClaims(claims)
+	if !ok || !c.now().Before(expiresAt) {
+		return
+	}
+	key := hashToken(token)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		entry := e.Value.(*claimsCacheEntry)
+		entry.claims = claims
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&claimsCacheEntry{key: key, claims: claims, expiresAt: expiresAt})
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*claimsCacheEntry).key)
+	}
+}
+
+// Len returns the number of entries currently held by the cache.
+func (c *ClaimsCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// hashToken hashes the raw token so it is not retained in memory.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// expiryFromClaims reads the `exp` claim as seconds since the Unix epoch.
+func expiryFromClaims(claims map[string]any) (time.Time, bool) {
+	var exp int64
+	switch v := claims["exp"].(type) {
+	case float64:
+		exp = int64(v)
+	case int64:
+		exp = v
+	case int:
+		exp = int64(v)
+	default:
+		return time.Time{}, false
+	}
+	return time.Unix(exp, 0), true
+}
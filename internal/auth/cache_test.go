@@ -0,0 +1,99 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClaimsCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+	exp := float64(now.Add(time.Hour).Unix())
+
+	newCache := func(t *testing.T, capacity int) *ClaimsCache {
+		c, err := NewClaimsCache("my-auth", capacity)
+		if err != nil {
+			t.Fatalf("unable to create cache: %s", err)
+		}
+		c.now = func() time.Time { return now }
+		return c
+	}
+
+	t.Run("hit after add", func(t *testing.T) {
+		c := newCache(t, 2)
+		want := map[string]any{"sub": "alice", "exp": exp}
+		if _, ok := c.Get(ctx, "token-a"); ok {
+			t.Fatalf("unexpected hit on empty cache")
+		}
+		c.Add("token-a", want)
+		got, ok := c.Get(ctx, "token-a")
+		if !ok {
+			t.Fatalf("expected hit after add")
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("incorrect claims: diff %v", diff)
+		}
+	})
+
+	t.Run("expired entry is a miss", func(t *testing.T) {
+		c := newCache(t, 2)
+		c.Add("token-a", map[string]any{"exp": exp})
+		c.now = func() time.Time { return now.Add(2 * time.Hour) }
+		if _, ok := c.Get(ctx, "token-a"); ok {
+			t.Fatalf("expected miss for expired token")
+		}
+		if c.Len() != 0 {
+			t.Fatalf("expired entry was not evicted")
+		}
+	})
+
+	t.Run("claims without exp are not cached", func(t *testing.T) {
+		c := newCache(t, 2)
+		c.Add("token-a", map[string]any{"sub": "alice"})
+		if c.Len() != 0 {
+			t.Fatalf("claims without exp should not be cached")
+		}
+	})
+
+	t.Run("least recently used entry is evicted", func(t *testing.T) {
+		c := newCache(t, 2)
+		c.Add("token-a", map[string]any{"exp": exp})
+		c.Add("token-b", map[string]any{"exp": exp})
+		// touch token-a so token-b becomes the oldest
+		if _, ok := c.Get(ctx, "token-a"); !ok {
+			t.Fatalf("expected hit for token-a")
+		}
+		c.Add("token-c", map[string]any{"exp": exp})
+		if _, ok := c.Get(ctx, "token-b"); ok {
+			t.Fatalf("expected token-b to be evicted")
+		}
+		for _, token := range []string{"token-a", "token-c"} {
+			if _, ok := c.Get(ctx, token); !ok {
+				t.Fatalf("expected hit for %s", token)
+			}
+		}
+	})
+
+	t.Run("invalid capacity", func(t *testing.T) {
+		if _, err := NewClaimsCache("my-auth", 0); err == nil {
+			t.Fatalf("expected error for zero capacity")
+		}
+	})
+}
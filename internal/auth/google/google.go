@@ -19,12 +19,25 @@ import (
 	"fmt"
 	"net/http"
 
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/auth"
 	"google.golang.org/api/idtoken"
 )
 
 const AuthServiceKind string = "google"
 
+func init() {
+	auth.Register(AuthServiceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (auth.AuthServiceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 // validate interface
 var _ auth.AuthServiceConfig = Config{}
 
@@ -42,10 +55,15 @@ func (cfg Config) AuthServiceConfigKind() string {
 
 // Initialize a Google auth service
 func (cfg Config) Initialize() (auth.AuthService, error) {
+	cache, err := auth.NewClaimsCache(cfg.Name, auth.DefaultClaimsCacheSize)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create claims cache: %w", err)
+	}
 	a := &AuthService{
 		Name:     cfg.Name,
 		Kind:     AuthServiceKind,
 		ClientID: cfg.ClientID,
+		cache:    cache,
 	}
 	return a, nil
 }
@@ -57,6 +75,9 @@ type AuthService struct {
 	Name     string `yaml:"name"`
 	Kind     string `yaml:"kind"`
 	ClientID string `yaml:"clientId"`
+
+	// cache holds claims of previously verified tokens until they expire.
+	cache *auth.ClaimsCache
 }
 
 // Returns the auth service kind
@@ -72,10 +93,18 @@ func (a AuthService) GetName() string {
 // Verifies Google ID token and return claims
 func (a AuthService) GetClaimsFromHeader(ctx context.Context, h http.Header) (map[string]any, error) {
 	if token := h.Get(a.Name + "_token"); token != "" {
+		if a.cache != nil {
+			if claims, ok := a.cache.Get(ctx, token); ok {
+				return claims, nil
+			}
+		}
 		payload, err := idtoken.Validate(ctx, token, a.ClientID)
 		if err != nil {
 			return nil, fmt.Errorf("Google ID token verification failure: %w", err)
 		}
+		if a.cache != nil {
+			a.cache.Add(token, payload.Claims)
+		}
 		return payload.Claims, nil
 	}
 	return nil, nil
@@ -0,0 +1,66 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// sqlTables reads tables with catalog queries run against a database/sql
+// database. Both queries take the schema as their only argument; the columns
+// query returns rows of table, table comment, column, data type and column
+// comment, and the indexes query rows of table, column and whether the column
+// is part of the primary key.
+func sqlTables(ctx context.Context, db *sql.DB, schema, columnsStatement, indexesStatement string) ([]Table, error) {
+	columns, err := queryRows(ctx, db, columnsStatement, schema, func(rows *sql.Rows) (catalogRow, error) {
+		var r catalogRow
+		err := rows.Scan(&r.table, &r.tableComment, &r.column, &r.dataType, &r.columnComment)
+		return r, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read columns: %w", err)
+	}
+	tables := groupColumns(schema, columns)
+
+	indexes, err := queryRows(ctx, db, indexesStatement, schema, func(rows *sql.Rows) (indexRow, error) {
+		var r indexRow
+		err := rows.Scan(&r.table, &r.column, &r.primary)
+		return r, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read indexes: %w", err)
+	}
+	addIndexes(tables, indexes)
+	return tables, nil
+}
+
+func queryRows[T any](ctx context.Context, db *sql.DB, statement string, arg any, scan func(*sql.Rows) (T, error)) ([]T, error) {
+	rows, err := db.QueryContext(ctx, statement, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []T
+	for rows.Next() {
+		v, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, v)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,412 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package generate creates tools from the catalog of a database or from an
+// OpenAPI document.
+package generate
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+)
+
+// Table is a table read from the catalog of a database.
+type Table struct {
+	// Schema is the schema of the table, if the dialect qualifies table names.
+	Schema  string
+	Name    string
+	Comment string
+	Columns []Column
+	// PrimaryKey lists the columns of the primary key, in key order.
+	PrimaryKey []string
+	// Indexed lists columns that are the first column of an index other than
+	// the primary key.
+	Indexed []string
+}
+
+// Column is a column of a Table.
+type Column struct {
+	Name string
+	// DataType is the type of the column as reported by the database.
+	DataType string
+	Comment  string
+}
+
+// column returns the named column of the table.
+func (t Table) column(name string) (Column, bool) {
+	i := slices.IndexFunc(t.Columns, func(c Column) bool { return c.Name == name })
+	if i < 0 {
+		return Column{}, false
+	}
+	return t.Columns[i], true
+}
+
+// Dialect describes how to read the catalog of a kind of database and how to
+// write statements for its tools.
+type Dialect struct {
+	// ToolKind is the kind of the generated tools.
+	ToolKind string
+	// DefaultSchema is the schema read when none is given.
+	DefaultSchema string
+	// Placeholder returns the placeholder of the i-th parameter, from 1.
+	Placeholder func(i int) string
+	// Quote quotes an identifier.
+	Quote func(name string) string
+	// Limit returns a query that returns at most the number of rows given by
+	// the placeholder.
+	Limit func(query, placeholder string) string
+	// ParameterType maps the type of a column to a parameter type, returning
+	// false if the column cannot be used as a parameter.
+	ParameterType func(dataType string) (string, bool)
+	// Tables reads the tables of a schema.
+	Tables func(ctx context.Context, schema string) ([]Table, error)
+	// qualify reports whether table names are qualified with their schema.
+	qualify bool
+}
+
+// dialectFactory returns the dialect of a source, or false if the source is
+// not a database of the dialect.
+type dialectFactory func(s sources.Source) (Dialect, bool)
+
+// NewDialect returns the dialect of the database of a source.
+func NewDialect(s sources.Source) (Dialect, error) {
+	for _, f := range []dialectFactory{postgresDialect, mysqlDialect, mssqlDialect, sqliteDialect} {
+		if d, ok := f(s); ok {
+			return d, nil
+		}
+	}
+	return Dialect{}, fmt.Errorf("tools cannot be generated for sources of kind %q", s.SourceKind())
+}
+
+// tableName returns the quoted, and possibly qualified, name of a table.
+func (d Dialect) tableName(t Table) string {
+	if d.qualify && t.Schema != "" {
+		return d.Quote(t.Schema) + "." + d.Quote(t.Name)
+	}
+	return d.Quote(t.Name)
+}
+
+// SQLToolConfig is the configuration of a generated SQL tool, as written to a
+// tools file.
+type SQLToolConfig struct {
+	Kind        string            `yaml:"kind"`
+	Source      string            `yaml:"source"`
+	Description string            `yaml:"description"`
+	Statement   string            `yaml:"statement"`
+	Parameters  []ParameterConfig `yaml:"parameters,omitempty"`
+}
+
+// ParameterConfig is the configuration of a parameter of a generated tool.
+type ParameterConfig struct {
+	Name        string `yaml:"name"`
+	Type        string `yaml:"type"`
+	Description string `yaml:"description"`
+	// Required is only set for optional parameters.
+	Required *bool `yaml:"required,omitempty"`
+	Default  any   `yaml:"default,omitempty"`
+	// Items is the configuration of the items of an array parameter.
+	Items *ParameterConfig `yaml:"items,omitempty"`
+	// Properties and AdditionalProperties configure the fields of an object
+	// parameter.
+	Properties           []ParameterConfig `yaml:"properties,omitempty"`
+	AdditionalProperties *ParameterConfig  `yaml:"additionalProperties,omitempty"`
+}
+
+// Tool is a generated tool and its name.
+type Tool struct {
+	Name string
+	// Config is the configuration written to the tools file, such as a
+	// SQLToolConfig or an HTTPToolConfig.
+	Config any
+}
+
+// Tools returns the tools generated for each table, against the named source:
+//
+//   - get-<table>-by-<key> gets a row by its primary key,
+//   - list-<table> lists rows whose text columns match optional LIKE patterns,
+//   - search-<table>-by-<column> lists rows by the value of an indexed column.
+func Tools(d Dialect, sourceName string, tables []Table) []Tool {
+	var out []Tool
+	for _, t := range tables {
+		g := toolGenerator{dialect: d, source: sourceName, table: t, name: toolName(t.Name)}
+		if tool, ok := g.getByPrimaryKey(); ok {
+			out = append(out, tool)
+		}
+		out = append(out, g.list())
+		for _, c := range t.Indexed {
+			if tool, ok := g.searchBy(c); ok {
+				out = append(out, tool)
+			}
+		}
+	}
+	return out
+}
+
+// Marshal writes the tools as the tools section of a tools file, along with a
+// toolset named after the source that lists all of them. The configuration of
+// the source is also written, unless it is nil.
+func Marshal(sourceName string, source any, generated []Tool) ([]byte, error) {
+	toolsSection := make(yaml.MapSlice, 0, len(generated))
+	names := make([]string, 0, len(generated))
+	for _, t := range generated {
+		toolsSection = append(toolsSection, yaml.MapItem{Key: t.Name, Value: t.Config})
+		names = append(names, t.Name)
+	}
+	var doc yaml.MapSlice
+	if source != nil {
+		doc = append(doc, yaml.MapItem{Key: "sources", Value: yaml.MapSlice{{Key: sourceName, Value: source}}})
+	}
+	doc = append(doc,
+		yaml.MapItem{Key: "tools", Value: toolsSection},
+		yaml.MapItem{Key: "toolsets", Value: yaml.MapSlice{{Key: toolName(sourceName), Value: names}}},
+	)
+	return yaml.MarshalWithOptions(doc, yaml.IndentSequence(true), yaml.UseLiteralStyleIfMultiline(true))
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// toolName turns an identifier into a valid part of a tool name.
+func toolName(s string) string {
+	return strings.Trim(invalidNameChars.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
+
+type toolGenerator struct {
+	dialect Dialect
+	source  string
+	table   Table
+	name    string
+}
+
+func (g toolGenerator) tool(name, description, statement string, params []ParameterConfig) Tool {
+	if g.table.Comment != "" {
+		description += " " + g.table.Comment
+	}
+	return Tool{
+		Name: name,
+		Config: SQLToolConfig{
+			Kind:        g.dialect.ToolKind,
+			Source:      g.source,
+			Description: description,
+			Statement:   statement,
+			Parameters:  params,
+		},
+	}
+}
+
+// parameter returns a parameter that takes the value of a column, or false if
+// the column cannot be used as a parameter.
+func (g toolGenerator) parameter(c Column, description string) (ParameterConfig, bool) {
+	t, ok := g.dialect.ParameterType(c.DataType)
+	if !ok {
+		return ParameterConfig{}, false
+	}
+	if c.Comment != "" {
+		description += " " + c.Comment
+	}
+	return ParameterConfig{Name: c.Name, Type: t, Description: description}, true
+}
+
+// defaultLimit is the number of rows returned when no limit is given.
+const defaultLimit = 100
+
+// limitParameter returns the parameter that limits the number of rows, named
+// "limit" unless one of the other parameters of the tool is, e.g. after a
+// column.
+func (g toolGenerator) limitParameter(params []ParameterConfig) ParameterConfig {
+	name := "limit"
+	for i := 2; slices.ContainsFunc(params, func(p ParameterConfig) bool { return p.Name == name }); i++ {
+		name = fmt.Sprintf("limit_%d", i)
+	}
+	return ParameterConfig{Name: name, Type: "integer", Description: "Maximum number of rows to return.", Default: defaultLimit}
+}
+
+func (g toolGenerator) getByPrimaryKey() (Tool, bool) {
+	key := g.table.PrimaryKey
+	if len(key) == 0 {
+		return Tool{}, false
+	}
+	var conditions []string
+	var params []ParameterConfig
+	for i, name := range key {
+		c, ok := g.table.column(name)
+		if !ok {
+			return Tool{}, false
+		}
+		p, ok := g.parameter(c, fmt.Sprintf("The %s of the row.", c.Name))
+		if !ok {
+			return Tool{}, false
+		}
+		conditions = append(conditions, fmt.Sprintf("%s = %s", g.dialect.Quote(c.Name), g.dialect.Placeholder(i+1)))
+		params = append(params, p)
+	}
+	keyName := "primary-key"
+	if len(key) == 1 {
+		keyName = toolName(key[0])
+	}
+	statement := fmt.Sprintf("SELECT * FROM %s WHERE %s", g.dialect.tableName(g.table), strings.Join(conditions, " AND "))
+	return g.tool(
+		fmt.Sprintf("get-%s-by-%s", g.name, keyName),
+		fmt.Sprintf("Get the row of the %s table with the given %s.", g.table.Name, strings.Join(key, " and ")),
+		statement,
+		params,
+	), true
+}
+
+func (g toolGenerator) list() Tool {
+	var conditions []string
+	var params []ParameterConfig
+	for _, c := range g.table.Columns {
+		if t, ok := g.dialect.ParameterType(c.DataType); !ok || t != "string" || !isText(c.DataType) {
+			continue
+		}
+		p, _ := g.parameter(c, fmt.Sprintf("LIKE pattern the %s of the rows must match, '%%' matches any value.", c.Name))
+		// omitted filters match every row
+		p.Default = "%"
+		conditions = append(conditions, fmt.Sprintf("COALESCE(%s, '') LIKE %s", g.dialect.Quote(c.Name), g.dialect.Placeholder(len(params)+1)))
+		params = append(params, p)
+	}
+	statement := "SELECT * FROM " + g.dialect.tableName(g.table)
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	statement = g.orderByKey(statement)
+	statement = g.dialect.Limit(statement, g.dialect.Placeholder(len(params)+1))
+	params = append(params, g.limitParameter(params))
+	return g.tool(
+		"list-"+g.name,
+		fmt.Sprintf("List rows of the %s table, filtered by their text columns.", g.table.Name),
+		statement,
+		params,
+	)
+}
+
+func (g toolGenerator) searchBy(name string) (Tool, bool) {
+	c, ok := g.table.column(name)
+	if !ok {
+		return Tool{}, false
+	}
+	p, ok := g.parameter(c, fmt.Sprintf("The %s of the rows.", c.Name))
+	if !ok {
+		return Tool{}, false
+	}
+	statement := fmt.Sprintf("SELECT * FROM %s WHERE %s = %s", g.dialect.tableName(g.table), g.dialect.Quote(c.Name), g.dialect.Placeholder(1))
+	statement = g.dialect.Limit(g.orderByKey(statement), g.dialect.Placeholder(2))
+	return g.tool(
+		fmt.Sprintf("search-%s-by-%s", g.name, toolName(c.Name)),
+		fmt.Sprintf("Search rows of the %s table by %s.", g.table.Name, c.Name),
+		statement,
+		[]ParameterConfig{p, g.limitParameter([]ParameterConfig{p})},
+	), true
+}
+
+// orderByKey orders the rows of a query by the primary key, if the table has one.
+func (g toolGenerator) orderByKey(query string) string {
+	if len(g.table.PrimaryKey) == 0 {
+		return query
+	}
+	quoted := make([]string, len(g.table.PrimaryKey))
+	for i, k := range g.table.PrimaryKey {
+		quoted[i] = g.dialect.Quote(k)
+	}
+	return query + " ORDER BY " + strings.Join(quoted, ", ")
+}
+
+// isText reports whether a column holds text that LIKE patterns can match.
+func isText(dataType string) bool {
+	t := strings.ToLower(dataType)
+	return strings.Contains(t, "char") || strings.Contains(t, "text") || strings.Contains(t, "clob")
+}
+
+// baseType returns the lowercase name of a type without its modifiers, e.g.
+// "varchar" for "VARCHAR(255)".
+func baseType(dataType string) string {
+	t, _, _ := strings.Cut(strings.ToLower(dataType), "(")
+	return strings.TrimSpace(t)
+}
+
+// parameterType maps the standard SQL types shared by most databases to
+// parameter types.
+func parameterType(dataType string) (string, bool) {
+	switch t := baseType(dataType); {
+	case strings.HasSuffix(strings.TrimSpace(dataType), "[]"):
+		return "", false
+	case slices.Contains([]string{"smallint", "integer", "int", "bigint", "tinyint", "mediumint", "int2", "int4", "int8", "serial", "bigserial", "smallserial"}, t):
+		return "integer", true
+	case slices.Contains([]string{"real", "float", "double", "double precision", "numeric", "decimal", "float4", "float8", "money", "smallmoney"}, t):
+		return "float", true
+	case slices.Contains([]string{"boolean", "bool", "bit"}, t):
+		return "boolean", true
+	case slices.Contains([]string{"bytea", "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary", "image", "json", "jsonb", "geometry", "geography", "user-defined"}, t):
+		return "", false
+	default:
+		return "string", true
+	}
+}
+
+// groupColumns groups catalog rows, ordered by table, into tables.
+func groupColumns(schema string, rows []catalogRow) []Table {
+	var tables []Table
+	for _, r := range rows {
+		if len(tables) == 0 || tables[len(tables)-1].Name != r.table {
+			tables = append(tables, Table{Schema: schema, Name: r.table, Comment: r.tableComment})
+		}
+		t := &tables[len(tables)-1]
+		t.Columns = append(t.Columns, Column{Name: r.column, DataType: r.dataType, Comment: r.columnComment})
+	}
+	return tables
+}
+
+// catalogRow is a column of a table read from a catalog.
+type catalogRow struct {
+	table, tableComment, column, dataType, columnComment string
+}
+
+// addIndexes records the primary key and indexed columns of tables, given
+// rows of table name, column name and whether the column is part of the
+// primary key. Key columns must be in key order.
+func addIndexes(tables []Table, rows []indexRow) {
+	for _, r := range rows {
+		i := slices.IndexFunc(tables, func(t Table) bool { return t.Name == r.table })
+		if i < 0 {
+			continue
+		}
+		t := &tables[i]
+		switch {
+		case r.primary:
+			if !slices.Contains(t.PrimaryKey, r.column) {
+				t.PrimaryKey = append(t.PrimaryKey, r.column)
+			}
+		case !slices.Contains(t.Indexed, r.column):
+			t.Indexed = append(t.Indexed, r.column)
+		}
+	}
+	// columns of the primary key do not need a separate search tool
+	for i := range tables {
+		tables[i].Indexed = slices.DeleteFunc(tables[i].Indexed, func(c string) bool {
+			return len(tables[i].PrimaryKey) == 1 && tables[i].PrimaryKey[0] == c
+		})
+	}
+}
+
+// indexRow is a column of an index read from a catalog.
+type indexRow struct {
+	table, column string
+	primary       bool
+}
@@ -0,0 +1,227 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/generate"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
+	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	_ "modernc.org/sqlite"
+)
+
+var ordersTable = generate.Table{
+	Schema:  "shop",
+	Name:    "orders",
+	Comment: "Orders placed by customers.",
+	Columns: []generate.Column{
+		{Name: "id", DataType: "integer"},
+		{Name: "customer", DataType: "varchar(255)", Comment: "Email of the customer."},
+		{Name: "total", DataType: "numeric(10,2)"},
+		{Name: "tags", DataType: "text[]"},
+	},
+	PrimaryKey: []string{"id"},
+	Indexed:    []string{"customer"},
+}
+
+func TestTools(t *testing.T) {
+	tcs := []struct {
+		desc   string
+		source sources.Source
+		want   []string
+	}{
+		{
+			desc:   "postgres",
+			source: &postgres.Source{},
+			want: []string{
+				`SELECT * FROM "shop"."orders" WHERE "id" = $1`,
+				`SELECT * FROM "shop"."orders" WHERE COALESCE("customer", '') LIKE $1 ORDER BY "id" LIMIT $2`,
+				`SELECT * FROM "shop"."orders" WHERE "customer" = $1 ORDER BY "id" LIMIT $2`,
+			},
+		},
+		{
+			desc:   "mysql",
+			source: &mysql.Source{},
+			want: []string{
+				"SELECT * FROM `shop`.`orders` WHERE `id` = ?",
+				"SELECT * FROM `shop`.`orders` WHERE COALESCE(`customer`, '') LIKE ? ORDER BY `id` LIMIT ?",
+				"SELECT * FROM `shop`.`orders` WHERE `customer` = ? ORDER BY `id` LIMIT ?",
+			},
+		},
+		{
+			desc:   "mssql",
+			source: &mssql.Source{},
+			want: []string{
+				`SELECT * FROM [shop].[orders] WHERE [id] = @p1`,
+				`SELECT TOP (@p2) * FROM [shop].[orders] WHERE COALESCE([customer], '') LIKE @p1 ORDER BY [id]`,
+				`SELECT TOP (@p2) * FROM [shop].[orders] WHERE [customer] = @p1 ORDER BY [id]`,
+			},
+		},
+		{
+			desc:   "sqlite",
+			source: &sqlite.Source{},
+			want: []string{
+				`SELECT * FROM "orders" WHERE "id" = ?`,
+				`SELECT * FROM "orders" WHERE COALESCE("customer", '') LIKE ? ORDER BY "id" LIMIT ?`,
+				`SELECT * FROM "orders" WHERE "customer" = ? ORDER BY "id" LIMIT ?`,
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			d, err := generate.NewDialect(tc.source)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			got := generate.Tools(d, "my-db", []generate.Table{ordersTable})
+			var names, statements []string
+			for _, tool := range got {
+				names = append(names, tool.Name)
+				cfg := tool.Config.(generate.SQLToolConfig)
+				statements = append(statements, cfg.Statement)
+				if cfg.Kind != d.ToolKind || cfg.Source != "my-db" {
+					t.Fatalf("incorrect kind or source: %+v", cfg)
+				}
+			}
+			if diff := cmp.Diff([]string{"get-orders-by-id", "list-orders", "search-orders-by-customer"}, names); diff != "" {
+				t.Fatalf("incorrect tool names (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, statements); diff != "" {
+				t.Fatalf("incorrect statements (-want +got):\n%s", diff)
+			}
+
+			wantParams := []generate.ParameterConfig{
+				{Name: "customer", Type: "string", Description: "LIKE pattern the customer of the rows must match, '%' matches any value. Email of the customer.", Default: "%"},
+				{Name: "limit", Type: "integer", Description: "Maximum number of rows to return.", Default: 100},
+			}
+			if diff := cmp.Diff(wantParams, got[1].Config.(generate.SQLToolConfig).Parameters); diff != "" {
+				t.Fatalf("incorrect list parameters (-want +got):\n%s", diff)
+			}
+			wantDesc := "Get the row of the orders table with the given id. Orders placed by customers."
+			if got[0].Config.(generate.SQLToolConfig).Description != wantDesc {
+				t.Fatalf("incorrect description: got %q, want %q", got[0].Config.(generate.SQLToolConfig).Description, wantDesc)
+			}
+		})
+	}
+}
+
+func TestToolsLimitColumn(t *testing.T) {
+	d, err := generate.NewDialect(&postgres.Source{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	table := generate.Table{
+		Name: "quotas",
+		Columns: []generate.Column{
+			{Name: "id", DataType: "integer"},
+			{Name: "limit", DataType: "text"},
+		},
+		PrimaryKey: []string{"id"},
+		Indexed:    []string{"limit"},
+	}
+	got := generate.Tools(d, "my-db", []generate.Table{table})
+	if len(got) != 3 {
+		t.Fatalf("expected 3 tools, got %d", len(got))
+	}
+	// the limit parameter is renamed rather than colliding with the column
+	for _, tool := range got[1:] {
+		var names []string
+		for _, p := range tool.Config.(generate.SQLToolConfig).Parameters {
+			names = append(names, p.Name)
+		}
+		if diff := cmp.Diff([]string{"limit", "limit_2"}, names); diff != "" {
+			t.Fatalf("incorrect parameters of %s (-want +got):\n%s", tool.Name, diff)
+		}
+	}
+}
+
+func TestNewDialectUnsupported(t *testing.T) {
+	_, err := generate.NewDialect(&bigquery.Source{})
+	if want := `tools cannot be generated for sources of kind "bigquery"`; err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
+
+func TestSQLiteTables(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("unable to open database: %s", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	for _, stmt := range []string{
+		`CREATE TABLE orders (id INTEGER PRIMARY KEY, customer TEXT NOT NULL, total REAL, receipt BLOB)`,
+		`CREATE INDEX orders_by_customer ON orders (customer, total)`,
+		`CREATE TABLE order_items (order_id INTEGER, line INTEGER, sku VARCHAR(20), PRIMARY KEY (order_id, line))`,
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("unable to create fixture: %s", err)
+		}
+	}
+
+	d, err := generate.NewDialect(&sqlite.Source{Db: db})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := d.Tables(context.Background(), d.DefaultSchema)
+	if err != nil {
+		t.Fatalf("unable to read tables: %s", err)
+	}
+	want := []generate.Table{
+		{
+			Schema: "main",
+			Name:   "order_items",
+			Columns: []generate.Column{
+				{Name: "order_id", DataType: "INTEGER"},
+				{Name: "line", DataType: "INTEGER"},
+				{Name: "sku", DataType: "VARCHAR(20)"},
+			},
+			PrimaryKey: []string{"order_id", "line"},
+		},
+		{
+			Schema: "main",
+			Name:   "orders",
+			Columns: []generate.Column{
+				{Name: "id", DataType: "INTEGER"},
+				{Name: "customer", DataType: "TEXT"},
+				{Name: "total", DataType: "REAL"},
+				{Name: "receipt", DataType: "BLOB"},
+			},
+			PrimaryKey: []string{"id"},
+			Indexed:    []string{"customer"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect tables (-want +got):\n%s", diff)
+	}
+
+	tools := generate.Tools(d, "my-sqlite", got)
+	var names []string
+	for _, tool := range tools {
+		names = append(names, tool.Name)
+	}
+	wantNames := []string{"get-order_items-by-primary-key", "list-order_items", "get-orders-by-id", "list-orders", "search-orders-by-customer"}
+	if diff := cmp.Diff(wantNames, names); diff != "" {
+		t.Fatalf("incorrect tool names (-want +got):\n%s", diff)
+	}
+}
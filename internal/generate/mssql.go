@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools/mssqlsql"
+)
+
+const mssqlColumnsStatement = `
+SELECT t.name, COALESCE(CAST(tep.value AS NVARCHAR(MAX)), ''),
+	c.name, ty.name, COALESCE(CAST(cep.value AS NVARCHAR(MAX)), '')
+FROM sys.tables t
+JOIN sys.schemas s ON s.schema_id = t.schema_id
+JOIN sys.columns c ON c.object_id = t.object_id
+JOIN sys.types ty ON ty.user_type_id = c.user_type_id
+LEFT JOIN sys.extended_properties tep
+	ON tep.class = 1 AND tep.major_id = t.object_id AND tep.minor_id = 0 AND tep.name = 'MS_Description'
+LEFT JOIN sys.extended_properties cep
+	ON cep.class = 1 AND cep.major_id = t.object_id AND cep.minor_id = c.column_id AND cep.name = 'MS_Description'
+WHERE s.name = @p1
+ORDER BY t.name, c.column_id`
+
+const mssqlIndexesStatement = `
+SELECT t.name, c.name, i.is_primary_key
+FROM sys.indexes i
+JOIN sys.tables t ON t.object_id = i.object_id
+JOIN sys.schemas s ON s.schema_id = t.schema_id
+JOIN sys.index_columns ic ON ic.object_id = i.object_id AND ic.index_id = i.index_id
+JOIN sys.columns c ON c.object_id = ic.object_id AND c.column_id = ic.column_id
+WHERE s.name = @p1 AND ic.key_ordinal > 0 AND (i.is_primary_key = 1 OR ic.key_ordinal = 1)
+ORDER BY t.name, i.is_primary_key DESC, ic.key_ordinal`
+
+func mssqlDialect(s sources.Source) (Dialect, bool) {
+	src, ok := s.(interface{ MSSQLDB() *sql.DB })
+	if !ok {
+		return Dialect{}, false
+	}
+	db := src.MSSQLDB()
+	return Dialect{
+		ToolKind:      mssqlsql.ToolKind,
+		DefaultSchema: "dbo",
+		Placeholder:   func(i int) string { return "@p" + strconv.Itoa(i) },
+		Quote:         func(name string) string { return "[" + strings.ReplaceAll(name, "]", "]]") + "]" },
+		Limit: func(query, placeholder string) string {
+			return "SELECT TOP (" + placeholder + ") " + strings.TrimPrefix(query, "SELECT ")
+		},
+		ParameterType: parameterType,
+		Tables: func(ctx context.Context, schema string) ([]Table, error) {
+			return sqlTables(ctx, db, schema, mssqlColumnsStatement, mssqlIndexesStatement)
+		},
+		qualify: true,
+	}, true
+}
@@ -0,0 +1,71 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools/mysqlsql"
+)
+
+const mysqlColumnsStatement = `
+SELECT c.TABLE_NAME, t.TABLE_COMMENT, c.COLUMN_NAME, c.COLUMN_TYPE, c.COLUMN_COMMENT
+FROM information_schema.COLUMNS c
+JOIN information_schema.TABLES t ON t.TABLE_SCHEMA = c.TABLE_SCHEMA AND t.TABLE_NAME = c.TABLE_NAME
+WHERE c.TABLE_SCHEMA = ? AND t.TABLE_TYPE = 'BASE TABLE'
+ORDER BY c.TABLE_NAME, c.ORDINAL_POSITION`
+
+const mysqlIndexesStatement = `
+SELECT TABLE_NAME, COLUMN_NAME, INDEX_NAME = 'PRIMARY'
+FROM information_schema.STATISTICS
+WHERE TABLE_SCHEMA = ? AND (INDEX_NAME = 'PRIMARY' OR SEQ_IN_INDEX = 1)
+ORDER BY TABLE_NAME, INDEX_NAME = 'PRIMARY' DESC, SEQ_IN_INDEX`
+
+func mysqlDialect(s sources.Source) (Dialect, bool) {
+	src, ok := s.(interface{ MySQLPool() *sql.DB })
+	if !ok {
+		return Dialect{}, false
+	}
+	db := src.MySQLPool()
+	return Dialect{
+		ToolKind:      mysqlsql.ToolKind,
+		Placeholder:   func(int) string { return "?" },
+		Quote:         func(name string) string { return "`" + strings.ReplaceAll(name, "`", "``") + "`" },
+		Limit:         func(query, placeholder string) string { return query + " LIMIT " + placeholder },
+		ParameterType: mysqlParameterType,
+		Tables: func(ctx context.Context, schema string) ([]Table, error) {
+			if schema == "" {
+				// the database of the connection
+				if err := db.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&schema); err != nil {
+					return nil, fmt.Errorf("unable to read the current database: %w", err)
+				}
+			}
+			return sqlTables(ctx, db, schema, mysqlColumnsStatement, mysqlIndexesStatement)
+		},
+		qualify: true,
+	}, true
+}
+
+// mysqlParameterType maps MySQL column types, where tinyint(1) is a boolean.
+func mysqlParameterType(dataType string) (string, bool) {
+	if strings.EqualFold(dataType, "tinyint(1)") {
+		return "boolean", true
+	}
+	return parameterType(strings.TrimSuffix(strings.ToLower(dataType), " unsigned"))
+}
@@ -0,0 +1,486 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	yaml "github.com/goccy/go-yaml"
+)
+
+// HTTPSourceConfig is the configuration of a generated http source, as written
+// to a tools file.
+type HTTPSourceConfig struct {
+	Kind    string `yaml:"kind"`
+	BaseURL string `yaml:"baseUrl"`
+}
+
+// HTTPToolConfig is the configuration of a generated http tool, as written to a
+// tools file.
+type HTTPToolConfig struct {
+	Kind         string            `yaml:"kind"`
+	Source       string            `yaml:"source"`
+	Description  string            `yaml:"description"`
+	Method       string            `yaml:"method"`
+	Path         string            `yaml:"path"`
+	Headers      map[string]string `yaml:"headers,omitempty"`
+	RequestBody  string            `yaml:"requestBody,omitempty"`
+	PathParams   []ParameterConfig `yaml:"pathParams,omitempty"`
+	QueryParams  []ParameterConfig `yaml:"queryParams,omitempty"`
+	HeaderParams []ParameterConfig `yaml:"headerParams,omitempty"`
+	BodyParams   []ParameterConfig `yaml:"bodyParams,omitempty"`
+}
+
+// OpenAPIDocument is the subset of an OpenAPI 3 document used to generate
+// tools, with local references resolved.
+type OpenAPIDocument struct {
+	OpenAPI string                     `yaml:"openapi"`
+	Servers []openAPIServer            `yaml:"servers"`
+	Paths   map[string]openAPIPathItem `yaml:"paths"`
+}
+
+type openAPIServer struct {
+	URL       string `yaml:"url"`
+	Variables map[string]struct {
+		Default string `yaml:"default"`
+	} `yaml:"variables"`
+}
+
+type openAPIPathItem struct {
+	Parameters []openAPIParameter `yaml:"parameters"`
+	Get        *openAPIOperation  `yaml:"get"`
+	Put        *openAPIOperation  `yaml:"put"`
+	Post       *openAPIOperation  `yaml:"post"`
+	Delete     *openAPIOperation  `yaml:"delete"`
+	Patch      *openAPIOperation  `yaml:"patch"`
+}
+
+type openAPIOperation struct {
+	OperationID string              `yaml:"operationId"`
+	Summary     string              `yaml:"summary"`
+	Description string              `yaml:"description"`
+	Parameters  []openAPIParameter  `yaml:"parameters"`
+	RequestBody *openAPIRequestBody `yaml:"requestBody"`
+}
+
+type openAPIParameter struct {
+	Name        string         `yaml:"name"`
+	In          string         `yaml:"in"`
+	Description string         `yaml:"description"`
+	Required    bool           `yaml:"required"`
+	Schema      *openAPISchema `yaml:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Required bool `yaml:"required"`
+	Content  map[string]struct {
+		Schema *openAPISchema `yaml:"schema"`
+	} `yaml:"content"`
+}
+
+type openAPISchema struct {
+	// Type is a string, or a list of strings in OpenAPI 3.1.
+	Type        any                       `yaml:"type"`
+	Description string                    `yaml:"description"`
+	Items       *openAPISchema            `yaml:"items"`
+	Properties  map[string]*openAPISchema `yaml:"properties"`
+	Required    []string                  `yaml:"required"`
+	Default     any                       `yaml:"default"`
+	// AdditionalProperties is a boolean or a schema.
+	AdditionalProperties any `yaml:"additionalProperties"`
+}
+
+// typeName returns the type of the schema, ignoring "null" in OpenAPI 3.1 type
+// lists.
+func (s *openAPISchema) typeName() string {
+	switch t := s.Type.(type) {
+	case string:
+		return t
+	case []any:
+		for _, v := range t {
+			if name, ok := v.(string); ok && name != "null" {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// ParseOpenAPI parses an OpenAPI 3 document in YAML or JSON, resolving the
+// references to its components.
+func ParseOpenAPI(b []byte) (*OpenAPIDocument, error) {
+	var raw map[string]any
+	if err := yaml.Unmarshal(b, &raw); err != nil {
+		return nil, fmt.Errorf("unable to parse OpenAPI document: %w", err)
+	}
+	version, _ := raw["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q, only OpenAPI 3 documents are supported", version)
+	}
+	resolved, err := resolveRefs(raw, raw, nil)
+	if err != nil {
+		return nil, err
+	}
+	b, err = yaml.Marshal(resolved)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse OpenAPI document: %w", err)
+	}
+	var doc OpenAPIDocument
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, fmt.Errorf("unable to parse OpenAPI document: %w", err)
+	}
+	return &doc, nil
+}
+
+// resolveRefs replaces each local "$ref" in v by the value it refers to. The
+// references being resolved are tracked to reject recursive schemas.
+func resolveRefs(root map[string]any, v any, resolving []string) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			if slices.Contains(resolving, ref) {
+				return nil, fmt.Errorf("recursive reference %q is not supported", ref)
+			}
+			target, err := lookupRef(root, ref)
+			if err != nil {
+				return nil, err
+			}
+			return resolveRefs(root, target, append(resolving, ref))
+		}
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			r, err := resolveRefs(root, item, resolving)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = r
+		}
+		return out, nil
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			r, err := resolveRefs(root, item, resolving)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = r
+		}
+		return out, nil
+	}
+	return v, nil
+}
+
+// lookupRef returns the value a local reference such as
+// "#/components/schemas/Pet" refers to.
+func lookupRef(root map[string]any, ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, fmt.Errorf("reference %q is not supported, only local references are", ref)
+	}
+	var v any = root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unable to resolve reference %q", ref)
+		}
+		if v, ok = m[part]; !ok {
+			return nil, fmt.Errorf("unable to resolve reference %q", ref)
+		}
+	}
+	return v, nil
+}
+
+var serverVariable = regexp.MustCompile(`\{([^}]+)\}`)
+
+// BaseURL returns the URL of the first server of the document, with its
+// variables replaced by their default values.
+func (doc *OpenAPIDocument) BaseURL() string {
+	if len(doc.Servers) == 0 {
+		return ""
+	}
+	s := doc.Servers[0]
+	u := serverVariable.ReplaceAllStringFunc(s.URL, func(m string) string {
+		if v, ok := s.Variables[m[1:len(m)-1]]; ok {
+			return v.Default
+		}
+		return m
+	})
+	return strings.TrimSuffix(u, "/")
+}
+
+// SkippedOperation is an operation of an OpenAPI document that no tool was
+// generated for.
+type SkippedOperation struct {
+	Method string
+	Path   string
+	Reason string
+}
+
+// OpenAPITools returns an http tool for each operation of the document, against
+// the named source. Operations that need parameters of unsupported types are
+// skipped. Optional path, query and header parameters are generated as
+// optional parameters, and optional body properties are left out.
+func OpenAPITools(doc *OpenAPIDocument, sourceName string) ([]Tool, []SkippedOperation) {
+	var out []Tool
+	var skipped []SkippedOperation
+	names := make(map[string]bool)
+	for _, p := range slices.Sorted(maps.Keys(doc.Paths)) {
+		item := doc.Paths[p]
+		for _, op := range []struct {
+			method    string
+			operation *openAPIOperation
+		}{
+			{"GET", item.Get}, {"POST", item.Post}, {"PUT", item.Put}, {"PATCH", item.Patch}, {"DELETE", item.Delete},
+		} {
+			if op.operation == nil {
+				continue
+			}
+			cfg, err := httpTool(sourceName, op.method, p, item.Parameters, op.operation)
+			if err != nil {
+				skipped = append(skipped, SkippedOperation{Method: op.method, Path: p, Reason: err.Error()})
+				continue
+			}
+			name := operationName(op.method, p, op.operation.OperationID)
+			for i := 2; names[name]; i++ {
+				name = fmt.Sprintf("%s-%d", operationName(op.method, p, op.operation.OperationID), i)
+			}
+			names[name] = true
+			out = append(out, Tool{Name: name, Config: cfg})
+		}
+	}
+	return out, skipped
+}
+
+var camelCaseBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// operationName returns the name of the tool of an operation, from its
+// operationId or else from its method and path.
+func operationName(method, path, operationID string) string {
+	if operationID != "" {
+		return toolName(camelCaseBoundary.ReplaceAllString(operationID, "$1-$2"))
+	}
+	parts := []string{method}
+	for _, segment := range strings.Split(path, "/") {
+		if segment = strings.Trim(segment, "{}"); segment != "" {
+			parts = append(parts, segment)
+		}
+	}
+	return toolName(strings.Join(parts, "-"))
+}
+
+// httpTool returns the configuration of the http tool of an operation.
+func httpTool(sourceName, method, path string, shared []openAPIParameter, op *openAPIOperation) (HTTPToolConfig, error) {
+	cfg := HTTPToolConfig{
+		Kind:        "http",
+		Source:      sourceName,
+		Description: strings.TrimSpace(strings.TrimSpace(op.Summary) + "\n\n" + strings.TrimSpace(op.Description)),
+		Method:      method,
+		Path:        path,
+	}
+	if cfg.Description == "" {
+		cfg.Description = method + " " + path
+	}
+
+	// parameters of the operation override those of the path with the same
+	// name and location
+	params := slices.Clone(op.Parameters)
+	for _, p := range shared {
+		if !slices.ContainsFunc(params, func(o openAPIParameter) bool { return o.Name == p.Name && o.In == p.In }) {
+			params = append(params, p)
+		}
+	}
+	seen := make(map[string]bool)
+	for _, p := range params {
+		if p.In == "cookie" {
+			if !p.Required {
+				continue
+			}
+			return cfg, fmt.Errorf("cookie parameter %q is not supported", p.Name)
+		}
+		if seen[p.Name] {
+			return cfg, fmt.Errorf("parameter name %q is used more than once", p.Name)
+		}
+		seen[p.Name] = true
+		desc := p.Description
+		if p.Schema != nil && desc == "" {
+			desc = p.Schema.Description
+		}
+		param, err := openAPIParameterConfig(p.Name, fmt.Sprintf("The %s %s parameter.", p.Name, p.In), desc, p.Schema)
+		if err == nil && param.Type == "object" {
+			// only request bodies are sent as JSON
+			err = fmt.Errorf("%s parameter %q of type \"object\" is not supported", p.In, p.Name)
+		}
+		if err != nil {
+			if !p.Required && p.In != "path" {
+				// optional parameters of unsupported types are left out
+				continue
+			}
+			return cfg, err
+		}
+		// path parameters are always required
+		if !p.Required && p.In != "path" {
+			optional := false
+			param.Required = &optional
+			param.Default = p.Schema.Default
+		}
+		switch p.In {
+		case "path":
+			cfg.PathParams = append(cfg.PathParams, param)
+		case "query":
+			cfg.QueryParams = append(cfg.QueryParams, param)
+		case "header":
+			cfg.HeaderParams = append(cfg.HeaderParams, param)
+		default:
+			return cfg, fmt.Errorf("parameter %q has unknown location %q", p.Name, p.In)
+		}
+	}
+
+	if op.RequestBody == nil {
+		return cfg, nil
+	}
+	schema, ok := jsonSchema(op.RequestBody)
+	if !ok {
+		if op.RequestBody.Required {
+			return cfg, fmt.Errorf("request body must be a JSON object")
+		}
+		return cfg, nil
+	}
+	var fields []string
+	for _, name := range slices.Sorted(maps.Keys(schema.Properties)) {
+		if !slices.Contains(schema.Required, name) {
+			continue
+		}
+		if seen[name] {
+			return cfg, fmt.Errorf("parameter name %q is used more than once", name)
+		}
+		seen[name] = true
+		prop := schema.Properties[name]
+		param, err := openAPIParameterConfig(name, fmt.Sprintf("The %s property of the request body.", name), prop.Description, prop)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.BodyParams = append(cfg.BodyParams, param)
+		fields = append(fields, fmt.Sprintf("%q: {{json %s}}", name, templateField(name)))
+	}
+	cfg.Headers = map[string]string{"Content-Type": "application/json"}
+	cfg.RequestBody = "{" + strings.Join(fields, ", ") + "}"
+	return cfg, nil
+}
+
+// jsonSchema returns the schema of a JSON object request body.
+func jsonSchema(body *openAPIRequestBody) (*openAPISchema, bool) {
+	for mediaType, content := range body.Content {
+		if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+			continue
+		}
+		if content.Schema != nil && content.Schema.typeName() == "object" {
+			return content.Schema, true
+		}
+	}
+	return nil, false
+}
+
+var templateIdentifier = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// templateField returns the text/template expression of a body parameter.
+func templateField(name string) string {
+	if templateIdentifier.MatchString(name) {
+		return "." + name
+	}
+	return fmt.Sprintf("(index . %q)", name)
+}
+
+// openAPIParameterConfig maps a schema onto a parameter, using defaultDesc when
+// desc is empty.
+func openAPIParameterConfig(name, defaultDesc, desc string, schema *openAPISchema) (ParameterConfig, error) {
+	if desc = strings.TrimSpace(desc); desc == "" {
+		desc = defaultDesc
+	}
+	if schema == nil {
+		return ParameterConfig{}, fmt.Errorf("parameter %q has no schema", name)
+	}
+	t, ok := openAPIParameterType(schema.typeName())
+	if !ok {
+		return ParameterConfig{}, fmt.Errorf("parameter %q has unsupported type %q", name, schema.typeName())
+	}
+	param := ParameterConfig{Name: name, Type: t, Description: desc}
+	if t == "array" {
+		if schema.Items == nil {
+			return ParameterConfig{}, fmt.Errorf("array parameter %q has no items", name)
+		}
+		itemType, ok := openAPIParameterType(schema.Items.typeName())
+		if !ok || itemType == "array" || itemType == "object" {
+			return ParameterConfig{}, fmt.Errorf("array parameter %q has unsupported items of type %q", name, schema.Items.typeName())
+		}
+		itemDesc := schema.Items.Description
+		if itemDesc == "" {
+			itemDesc = "An item of " + name + "."
+		}
+		param.Items = &ParameterConfig{Name: name, Type: itemType, Description: itemDesc}
+	}
+	if t == "object" {
+		for _, propName := range slices.Sorted(maps.Keys(schema.Properties)) {
+			prop := schema.Properties[propName]
+			if prop == nil {
+				continue
+			}
+			propParam, err := openAPIParameterConfig(propName, fmt.Sprintf("The %s property of %s.", propName, name), prop.Description, prop)
+			if err != nil {
+				return ParameterConfig{}, err
+			}
+			if !slices.Contains(schema.Required, propName) {
+				optional := false
+				propParam.Required = &optional
+				propParam.Default = prop.Default
+			}
+			param.Properties = append(param.Properties, propParam)
+		}
+		// decode the schema of additional properties through its map form
+		if values, ok := schema.AdditionalProperties.(map[string]any); ok {
+			b, err := yaml.Marshal(values)
+			if err != nil {
+				return ParameterConfig{}, err
+			}
+			var valueSchema openAPISchema
+			if err := yaml.Unmarshal(b, &valueSchema); err != nil {
+				return ParameterConfig{}, err
+			}
+			valueParam, err := openAPIParameterConfig(name, "A value of "+name+".", valueSchema.Description, &valueSchema)
+			if err != nil {
+				return ParameterConfig{}, err
+			}
+			param.AdditionalProperties = &valueParam
+		}
+		if len(param.Properties) == 0 && param.AdditionalProperties == nil {
+			return ParameterConfig{}, fmt.Errorf("object parameter %q has no properties", name)
+		}
+	}
+	return param, nil
+}
+
+// openAPIParameterType maps an OpenAPI type onto a parameter type.
+func openAPIParameterType(t string) (string, bool) {
+	switch t {
+	case "string", "boolean", "integer", "array", "object":
+		return t, true
+	case "number":
+		return "float", true
+	}
+	return "", false
+}
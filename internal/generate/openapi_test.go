@@ -0,0 +1,224 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/generate"
+)
+
+const petstore = `
+openapi: 3.0.3
+servers:
+  - url: https://{region}.example.com/v1/
+    variables:
+      region:
+        default: us
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets.
+      parameters:
+        - name: species
+          in: query
+          required: true
+          schema:
+            type: string
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            default: 20
+        - $ref: '#/components/parameters/RequestID'
+    post:
+      summary: Create a pet.
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/NewPet'
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        description: ID of the pet.
+        schema:
+          type: integer
+    get:
+      operationId: getPetById
+      description: Get a pet by its ID.
+    delete:
+      parameters:
+        - name: session
+          in: cookie
+          required: true
+          schema:
+            type: string
+components:
+  parameters:
+    RequestID:
+      name: X-Request-ID
+      in: header
+      required: true
+      schema:
+        type: string
+        description: ID of the request.
+  schemas:
+    NewPet:
+      type: object
+      required: [name, tags, weight, owner, labels]
+      properties:
+        name:
+          type: string
+        owner:
+          type: object
+          required: [id]
+          properties:
+            id:
+              type: integer
+            email:
+              type: string
+              description: Email of the owner.
+        labels:
+          type: object
+          additionalProperties:
+            type: string
+        tags:
+          type: array
+          items:
+            type: string
+        weight:
+          type: number
+          description: Weight in kilograms.
+        nickname:
+          type: string
+`
+
+func TestOpenAPITools(t *testing.T) {
+	doc, err := generate.ParseOpenAPI([]byte(petstore))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, want := doc.BaseURL(), "https://us.example.com/v1"; got != want {
+		t.Fatalf("incorrect base URL: got %q, want %q", got, want)
+	}
+
+	got, skipped := generate.OpenAPITools(doc, "petstore")
+	optional := false
+	want := []generate.Tool{
+		{
+			Name: "list-pets",
+			Config: generate.HTTPToolConfig{
+				Kind:        "http",
+				Source:      "petstore",
+				Description: "List pets.",
+				Method:      "GET",
+				Path:        "/pets",
+				QueryParams: []generate.ParameterConfig{
+					{Name: "species", Type: "string", Description: "The species query parameter."},
+					{Name: "limit", Type: "integer", Description: "The limit query parameter.", Required: &optional, Default: uint64(20)},
+				},
+				HeaderParams: []generate.ParameterConfig{
+					{Name: "X-Request-ID", Type: "string", Description: "ID of the request."},
+				},
+			},
+		},
+		{
+			Name: "post-pets",
+			Config: generate.HTTPToolConfig{
+				Kind:        "http",
+				Source:      "petstore",
+				Description: "Create a pet.",
+				Method:      "POST",
+				Path:        "/pets",
+				Headers:     map[string]string{"Content-Type": "application/json"},
+				RequestBody: `{"labels": {{json .labels}}, "name": {{json .name}}, "owner": {{json .owner}}, "tags": {{json .tags}}, "weight": {{json .weight}}}`,
+				BodyParams: []generate.ParameterConfig{
+					{
+						Name:                 "labels",
+						Type:                 "object",
+						Description:          "The labels property of the request body.",
+						AdditionalProperties: &generate.ParameterConfig{Name: "labels", Type: "string", Description: "A value of labels."},
+					},
+					{Name: "name", Type: "string", Description: "The name property of the request body."},
+					{
+						Name:        "owner",
+						Type:        "object",
+						Description: "The owner property of the request body.",
+						Properties: []generate.ParameterConfig{
+							{Name: "email", Type: "string", Description: "Email of the owner.", Required: &optional},
+							{Name: "id", Type: "integer", Description: "The id property of owner."},
+						},
+					},
+					{
+						Name:        "tags",
+						Type:        "array",
+						Description: "The tags property of the request body.",
+						Items:       &generate.ParameterConfig{Name: "tags", Type: "string", Description: "An item of tags."},
+					},
+					{Name: "weight", Type: "float", Description: "Weight in kilograms."},
+				},
+			},
+		},
+		{
+			Name: "get-pet-by-id",
+			Config: generate.HTTPToolConfig{
+				Kind:        "http",
+				Source:      "petstore",
+				Description: "Get a pet by its ID.",
+				Method:      "GET",
+				Path:        "/pets/{petId}",
+				PathParams: []generate.ParameterConfig{
+					{Name: "petId", Type: "integer", Description: "ID of the pet."},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect tools (-want +got):\n%s", diff)
+	}
+	wantSkipped := []generate.SkippedOperation{
+		{Method: "DELETE", Path: "/pets/{petId}", Reason: `cookie parameter "session" is not supported`},
+	}
+	if diff := cmp.Diff(wantSkipped, skipped); diff != "" {
+		t.Fatalf("incorrect skipped operations (-want +got):\n%s", diff)
+	}
+}
+
+func TestFailParseOpenAPI(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{desc: "swagger 2", in: "swagger: '2.0'\npaths: {}\n", err: `unsupported OpenAPI version ""`},
+		{desc: "missing reference", in: "openapi: 3.0.0\npaths:\n  /a:\n    $ref: '#/components/pathItems/A'\n", err: `unable to resolve reference "#/components/pathItems/A"`},
+		{desc: "remote reference", in: "openapi: 3.0.0\npaths:\n  /a:\n    $ref: 'other.yaml#/A'\n", err: `reference "other.yaml#/A" is not supported`},
+		{desc: "recursive reference", in: "openapi: 3.0.0\ncomponents:\n  schemas:\n    Node:\n      type: object\n      properties:\n        next:\n          $ref: '#/components/schemas/Node'\npaths:\n  /a:\n    post:\n      requestBody:\n        content:\n          application/json:\n            schema:\n              $ref: '#/components/schemas/Node'\n", err: `recursive reference "#/components/schemas/Node"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := generate.ParseOpenAPI([]byte(tc.in))
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want substring %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools/postgressql"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const postgresColumnsStatement = `
+SELECT c.relname, COALESCE(obj_description(c.oid, 'pg_class'), ''),
+	a.attname, format_type(a.atttypid, a.atttypmod), COALESCE(col_description(c.oid, a.attnum), '')
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum > 0 AND NOT a.attisdropped
+WHERE n.nspname = $1 AND c.relkind IN ('r', 'p')
+ORDER BY c.relname, a.attnum`
+
+const postgresIndexesStatement = `
+SELECT c.relname, a.attname, i.indisprimary
+FROM pg_index i
+JOIN pg_class c ON c.oid = i.indrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN LATERAL unnest(i.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+JOIN pg_attribute a ON a.attrelid = c.oid AND a.attnum = k.attnum
+WHERE n.nspname = $1 AND (i.indisprimary OR k.ord = 1)
+ORDER BY c.relname, i.indisprimary DESC, k.ord`
+
+func postgresDialect(s sources.Source) (Dialect, bool) {
+	src, ok := s.(interface{ PostgresPool() *pgxpool.Pool })
+	if !ok {
+		return Dialect{}, false
+	}
+	pool := src.PostgresPool()
+	return Dialect{
+		ToolKind:      postgressql.ToolKind,
+		DefaultSchema: "public",
+		Placeholder:   func(i int) string { return "$" + strconv.Itoa(i) },
+		Quote:         func(name string) string { return `"` + strings.ReplaceAll(name, `"`, `""`) + `"` },
+		Limit:         func(query, placeholder string) string { return query + " LIMIT " + placeholder },
+		ParameterType: parameterType,
+		Tables: func(ctx context.Context, schema string) ([]Table, error) {
+			return postgresTables(ctx, pool, schema)
+		},
+		qualify: true,
+	}, true
+}
+
+func postgresTables(ctx context.Context, pool *pgxpool.Pool, schema string) ([]Table, error) {
+	rows, err := pool.Query(ctx, postgresColumnsStatement, schema)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read columns: %w", err)
+	}
+	var columns []catalogRow
+	for rows.Next() {
+		var r catalogRow
+		if err := rows.Scan(&r.table, &r.tableComment, &r.column, &r.dataType, &r.columnComment); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("unable to read columns: %w", err)
+		}
+		columns = append(columns, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read columns: %w", err)
+	}
+	tables := groupColumns(schema, columns)
+
+	rows, err = pool.Query(ctx, postgresIndexesStatement, schema)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read indexes: %w", err)
+	}
+	var indexes []indexRow
+	for rows.Next() {
+		var r indexRow
+		if err := rows.Scan(&r.table, &r.column, &r.primary); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("unable to read indexes: %w", err)
+		}
+		indexes = append(indexes, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read indexes: %w", err)
+	}
+	addIndexes(tables, indexes)
+	return tables, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+)
+
+// SQLite has no comments, and its catalog is read through pragma functions.
+const sqliteColumnsStatement = `
+SELECT l.name, '', c.name, c.type, ''
+FROM pragma_table_list AS l
+JOIN pragma_table_info(l.name, l.schema) AS c
+WHERE l.schema = ?1 AND l.type = 'table' AND l.name NOT LIKE 'sqlite_%'
+ORDER BY l.name, c.cid`
+
+const sqliteIndexesStatement = `
+SELECT tbl, col, is_primary FROM (
+	SELECT l.name AS tbl, c.name AS col, 1 AS is_primary, c.pk AS ord
+	FROM pragma_table_list AS l
+	JOIN pragma_table_info(l.name, l.schema) AS c
+	WHERE l.schema = ?1 AND l.type = 'table' AND c.pk > 0
+	UNION ALL
+	SELECT l.name, ii.name, 0, 0
+	FROM pragma_table_list AS l
+	JOIN pragma_index_list(l.name) AS il
+	JOIN pragma_index_info(il.name) AS ii
+	WHERE l.schema = ?1 AND l.type = 'table' AND il.origin <> 'pk' AND ii.seqno = 0
+)
+ORDER BY tbl, is_primary DESC, ord`
+
+func sqliteDialect(s sources.Source) (Dialect, bool) {
+	src, ok := s.(interface{ SQLiteDB() *sql.DB })
+	if !ok {
+		return Dialect{}, false
+	}
+	db := src.SQLiteDB()
+	return Dialect{
+		ToolKind:      sqlitesql.ToolKind,
+		DefaultSchema: "main",
+		Placeholder:   func(int) string { return "?" },
+		Quote:         func(name string) string { return `"` + strings.ReplaceAll(name, `"`, `""`) + `"` },
+		Limit:         func(query, placeholder string) string { return query + " LIMIT " + placeholder },
+		ParameterType: sqliteParameterType,
+		Tables: func(ctx context.Context, schema string) ([]Table, error) {
+			return sqlTables(ctx, db, schema, sqliteColumnsStatement, sqliteIndexesStatement)
+		},
+	}, true
+}
+
+// sqliteParameterType maps declared types using the rules SQLite uses to
+// determine the affinity of a column.
+func sqliteParameterType(dataType string) (string, bool) {
+	t := strings.ToUpper(dataType)
+	switch {
+	case strings.HasSuffix(t, "[]"):
+		return "", false
+	case strings.Contains(t, "INT"):
+		return "integer", true
+	case strings.Contains(t, "CHAR"), strings.Contains(t, "CLOB"), strings.Contains(t, "TEXT"):
+		return "string", true
+	case t == "", strings.Contains(t, "BLOB"):
+		return "", false
+	case strings.Contains(t, "REAL"), strings.Contains(t, "FLOA"), strings.Contains(t, "DOUB"):
+		return "float", true
+	case strings.Contains(t, "BOOL"):
+		return "boolean", true
+	default:
+		return parameterType(dataType)
+	}
+}
@@ -0,0 +1,313 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/goccy/go-yaml/lexer"
+	"github.com/goccy/go-yaml/token"
+	"google.golang.org/api/option"
+	secretmanager "google.golang.org/api/secretmanager/v1"
+)
+
+// Resolver resolves a secret reference to its value. For "${file:/path}" the
+// reference passed to the Resolver registered for "file" is "/path".
+type Resolver interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// Resolvers maps a reference scheme to the Resolver responsible for it.
+type Resolvers map[string]Resolver
+
+// DefaultResolvers returns the resolvers available out of the box.
+func DefaultResolvers() Resolvers {
+	return Resolvers{
+		FileScheme:          FileResolver{},
+		SecretManagerScheme: &SecretManagerResolver{},
+	}
+}
+
+// Offline returns resolvers for the same schemes that leave secret references
+// as they are instead of accessing the secrets, so that a configuration can be
+// checked without credentials. Environment variables are still expanded.
+func (rs Resolvers) Offline() Resolvers {
+	out := make(Resolvers, len(rs))
+	for scheme := range rs {
+		out[scheme] = unresolved(scheme)
+	}
+	return out
+}
+
+// unresolved is a Resolver that returns references of its scheme as is.
+type unresolved string
+
+func (s unresolved) Resolve(_ context.Context, ref string) (string, error) {
+	return "${" + string(s) + ":" + ref + "}", nil
+}
+
+var (
+	reference     = regexp.MustCompile(`\$\{([^{}]+)\}`)
+	envName       = regexp.MustCompile(`^\w+$`)
+	envDefault    = regexp.MustCompile(`^(\w+):-(.*)$`)
+	schemeWithRef = regexp.MustCompile(`^([a-zA-Z][a-zA-Z0-9_-]*):(.+)$`)
+)
+
+// Expand replaces every reference in the YAML input with its value:
+//
+//	${VAR}           value of the environment variable VAR, which must be set
+//	${VAR:-default}  value of VAR, or default if VAR is unset or empty
+//	${scheme:ref}    value returned by the Resolver registered for scheme
+//
+// Values that cannot change how the scalar they appear in is read, such as a
+// port or a user name, are inserted as is. Otherwise the scalar is rewritten
+// as a double-quoted string, so that values with characters such as ": ",
+// " #" or line breaks are read back unchanged. References in comments are left
+// as is. All unresolved references are reported together.
+func (rs Resolvers) Expand(ctx context.Context, input string) (string, error) {
+	matches := reference.FindAllStringSubmatchIndex(input, -1)
+	if len(matches) == 0 {
+		return input, nil
+	}
+
+	// replace each reference with a placeholder that reads as part of any
+	// scalar, to find the scalar each reference appears in
+	prefix := "toolboxref"
+	for strings.Contains(input, prefix) {
+		prefix += "x"
+	}
+	placeholder := regexp.MustCompile(prefix + `(\d+)_`)
+	refs := make([]expandedRef, len(matches))
+	var b strings.Builder
+	last := 0
+	for i, m := range matches {
+		b.WriteString(input[last:m[0]])
+		refs[i] = expandedRef{text: input[m[0]:m[1]], expr: input[m[2]:m[3]], pos: b.Len()}
+		fmt.Fprintf(&b, "%s%d_", prefix, i)
+		refs[i].end = b.Len()
+		last = m[1]
+	}
+	b.WriteString(input[last:])
+	src := b.String()
+
+	var errs []error
+	var edits []edit
+	tokens := lexer.Tokenize(src)
+	for i, tk := range tokens {
+		found := placeholder.FindAllStringSubmatch(tk.Value, -1)
+		if len(found) == 0 || tk.Type == token.CommentType {
+			// references in comments are not resolved
+			continue
+		}
+		inToken := make([]int, 0, len(found))
+		safe := true
+		for _, f := range found {
+			j, _ := strconv.Atoi(f[1])
+			r := &refs[j]
+			v, err := rs.resolve(ctx, r.expr)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			r.value, r.resolved = v, true
+			inToken = append(inToken, j)
+			safe = safe && insertableAsIs(tk.Type, v)
+		}
+		if len(inToken) == 0 {
+			continue
+		}
+		block := i > 0 && (tokens[i-1].Type == token.LiteralType || tokens[i-1].Type == token.FoldedType)
+		switch {
+		case block:
+			// line breaks of values are indented as the line they appear in
+			for _, j := range inToken {
+				line := src[strings.LastIndex(src[:refs[j].pos], "\n")+1:]
+				indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+				refs[j].value = strings.ReplaceAll(refs[j].value, "\n", "\n"+indent)
+			}
+		case !safe:
+			// rewrite the whole scalar as a double-quoted string
+			text := strings.TrimSpace(tk.Origin)
+			first := refs[inToken[0]]
+			start := first.pos - strings.Index(text, src[first.pos:first.end])
+			if start < 0 || !strings.HasPrefix(src[start:], text) {
+				return "", fmt.Errorf("unable to find the value of %s", first.text)
+			}
+			value := placeholder.ReplaceAllStringFunc(tk.Value, func(m string) string {
+				j, _ := strconv.Atoi(placeholder.FindStringSubmatch(m)[1])
+				return refs[j].value
+			})
+			edits = append(edits, edit{start: start, end: start + len(text), text: strconv.Quote(value)})
+		}
+	}
+	if len(errs) > 0 {
+		return "", errors.Join(errs...)
+	}
+
+	// references not in a rewritten scalar are inserted as is
+	for _, r := range refs {
+		text := r.text
+		if r.resolved {
+			text = r.value
+		}
+		if !slices.ContainsFunc(edits, func(e edit) bool { return e.start <= r.pos && r.end <= e.end }) {
+			edits = append(edits, edit{start: r.pos, end: r.end, text: text})
+		}
+	}
+	slices.SortFunc(edits, func(a, b edit) int { return a.start - b.start })
+	b.Reset()
+	last = 0
+	for _, e := range edits {
+		b.WriteString(src[last:e.start])
+		b.WriteString(e.text)
+		last = e.end
+	}
+	b.WriteString(src[last:])
+	return b.String(), nil
+}
+
+// expandedRef is a reference of an input to Expand, at [pos, end) of the
+// input with placeholders.
+type expandedRef struct {
+	text, expr string
+	pos, end   int
+	value      string
+	resolved   bool
+}
+
+// edit replaces [start, end) of the input with placeholders with text.
+type edit struct {
+	start, end int
+	text       string
+}
+
+// plainSafe matches values that read back unchanged in any plain scalar.
+var plainSafe = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_./+=~@-]*$`)
+
+// insertableAsIs reports whether a value reads back unchanged when inserted
+// into a scalar of the given type as is.
+func insertableAsIs(t token.Type, v string) bool {
+	switch t {
+	case token.DoubleQuoteType:
+		return !strings.ContainsAny(v, "\"\\\r\n")
+	case token.SingleQuoteType:
+		return !strings.ContainsAny(v, "'\r\n")
+	case token.StringType:
+		return plainSafe.MatchString(v)
+	default:
+		// not a scalar, such as an anchor
+		return true
+	}
+}
+
+func (rs Resolvers) resolve(ctx context.Context, expr string) (string, error) {
+	switch {
+	case envName.MatchString(expr):
+		v, ok := os.LookupEnv(expr)
+		if !ok {
+			return "", fmt.Errorf("environment variable %q is not set", expr)
+		}
+		return v, nil
+	case envDefault.MatchString(expr):
+		parts := envDefault.FindStringSubmatch(expr)
+		if v := os.Getenv(parts[1]); v != "" {
+			return v, nil
+		}
+		return parts[2], nil
+	case schemeWithRef.MatchString(expr):
+		parts := schemeWithRef.FindStringSubmatch(expr)
+		r, ok := rs[parts[1]]
+		if !ok {
+			return "", fmt.Errorf("unknown secret scheme %q in ${%s}", parts[1], expr)
+		}
+		v, err := r.Resolve(ctx, parts[2])
+		if err != nil {
+			return "", fmt.Errorf("unable to resolve ${%s}: %w", expr, err)
+		}
+		return v, nil
+	default:
+		return "", fmt.Errorf("invalid reference ${%s}", expr)
+	}
+}
+
+// FileScheme is the scheme for secrets mounted as files, e.g. "${file:/etc/secrets/password}".
+const FileScheme = "file"
+
+var _ Resolver = FileResolver{}
+
+// FileResolver reads a secret from a file. A single trailing newline is removed.
+type FileResolver struct{}
+
+func (FileResolver) Resolve(_ context.Context, ref string) (string, error) {
+	b, err := os.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	v := strings.TrimSuffix(string(b), "\n")
+	return strings.TrimSuffix(v, "\r"), nil
+}
+
+// SecretManagerScheme is the scheme for Google Cloud Secret Manager secrets,
+// e.g. "${secretmanager:projects/my-project/secrets/db-password/versions/latest}".
+const SecretManagerScheme = "secretmanager"
+
+var _ Resolver = &SecretManagerResolver{}
+
+// SecretManagerResolver accesses secret versions in Google Cloud Secret
+// Manager using Application Default Credentials. References without a version
+// resolve to the latest version.
+type SecretManagerResolver struct {
+	// ClientOptions are used when creating the client, e.g. to override the endpoint.
+	ClientOptions []option.ClientOption
+
+	once    sync.Once
+	service *secretmanager.Service
+	err     error
+}
+
+func (r *SecretManagerResolver) Resolve(ctx context.Context, ref string) (string, error) {
+	r.once.Do(func() {
+		r.service, r.err = secretmanager.NewService(ctx, r.ClientOptions...)
+	})
+	if r.err != nil {
+		return "", fmt.Errorf("unable to create Secret Manager client: %w", r.err)
+	}
+
+	name := strings.TrimPrefix(ref, "/")
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	resp, err := r.service.Projects.Secrets.Versions.Access(name).Context(ctx).Do()
+	if err != nil {
+		return "", fmt.Errorf("unable to access secret version %q: %w", name, err)
+	}
+	if resp.Payload == nil {
+		return "", fmt.Errorf("secret version %q has no payload", name)
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("unable to decode secret version %q: %w", name, err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,245 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/googleapis/genai-toolbox/internal/secrets"
+	"google.golang.org/api/option"
+)
+
+type staticResolver map[string]string
+
+func (r staticResolver) Resolve(_ context.Context, ref string) (string, error) {
+	return r[ref], nil
+}
+
+func TestExpand(t *testing.T) {
+	t.Setenv("SECRETS_TEST_USER", "alice")
+	t.Setenv("SECRETS_TEST_EMPTY", "")
+
+	dir := t.TempDir()
+	passwordFile := filepath.Join(dir, "password")
+	if err := os.WriteFile(passwordFile, []byte("hunter2\n"), 0o600); err != nil {
+		t.Fatalf("unable to write secret file: %s", err)
+	}
+
+	resolvers := secrets.DefaultResolvers()
+	resolvers["vault"] = staticResolver{"db/password": "s3cret"}
+
+	tcs := []struct {
+		desc string
+		in   string
+		want string
+	}{
+		{
+			desc: "environment variable",
+			in:   "user: ${SECRETS_TEST_USER}",
+			want: "user: alice",
+		},
+		{
+			desc: "empty environment variable",
+			in:   "user: '${SECRETS_TEST_EMPTY}'",
+			want: "user: ''",
+		},
+		{
+			desc: "default for unset variable",
+			in:   "port: ${SECRETS_TEST_UNSET:-5432}",
+			want: "port: 5432",
+		},
+		{
+			desc: "default for empty variable",
+			in:   "host: ${SECRETS_TEST_EMPTY:-localhost}",
+			want: "host: localhost",
+		},
+		{
+			desc: "default ignored when set",
+			in:   "user: ${SECRETS_TEST_USER:-bob}",
+			want: "user: alice",
+		},
+		{
+			desc: "file",
+			in:   "password: ${file:" + passwordFile + "}",
+			want: "password: hunter2",
+		},
+		{
+			desc: "custom resolver",
+			in:   "password: ${vault:db/password}",
+			want: "password: s3cret",
+		},
+		{
+			desc: "no references",
+			in:   "statement: SELECT $1",
+			want: "statement: SELECT $1",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := resolvers.Expand(context.Background(), tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect expansion: got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpandQuotesValues(t *testing.T) {
+	values := []string{"p@ss: word", "p@ss #word", "*alias", "&anchor", "!tag", "line 1\nline 2", `say "hi" \o/`, "it's", "- item", "{a: b}", ""}
+	contexts := []struct {
+		desc string
+		in   string
+		want func(v string) string
+	}{
+		{desc: "plain", in: "password: ${vault:v}", want: func(v string) string { return v }},
+		{desc: "embedded", in: "password: x-${vault:v}-y # ${UNSET}", want: func(v string) string { return "x-" + v + "-y" }},
+		{desc: "double-quoted", in: `password: "x-${vault:v}"`, want: func(v string) string { return "x-" + v }},
+		{desc: "single-quoted", in: "password: 'x-${vault:v}'", want: func(v string) string { return "x-" + v }},
+		{desc: "flow", in: "{password: ${vault:v}, user: alice}", want: func(v string) string { return v }},
+		{desc: "literal", in: "password: |\n  ${vault:v}\n  x\n", want: func(v string) string { return v + "\nx\n" }},
+	}
+	for _, v := range values {
+		resolvers := secrets.Resolvers{"vault": staticResolver{"v": v}}
+		for _, c := range contexts {
+			t.Run(c.desc+" "+v, func(t *testing.T) {
+				got, err := resolvers.Expand(context.Background(), c.in)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				var decoded map[string]any
+				if err := yaml.Unmarshal([]byte(got), &decoded); err != nil {
+					t.Fatalf("unable to parse expansion %q: %s", got, err)
+				}
+				if decoded["password"] != c.want(v) {
+					t.Fatalf("incorrect value of expansion %q: got %q, want %q", got, decoded["password"], c.want(v))
+				}
+			})
+		}
+	}
+}
+
+func TestFailExpand(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   string
+		errs []string
+	}{
+		{
+			desc: "unset variable",
+			in:   "password: ${SECRETS_TEST_UNSET}",
+			errs: []string{`environment variable "SECRETS_TEST_UNSET" is not set`},
+		},
+		{
+			desc: "all errors reported",
+			in:   "user: ${SECRETS_TEST_UNSET_1}\npassword: ${SECRETS_TEST_UNSET_2}",
+			errs: []string{
+				`environment variable "SECRETS_TEST_UNSET_1" is not set`,
+				`environment variable "SECRETS_TEST_UNSET_2" is not set`,
+			},
+		},
+		{
+			desc: "unknown scheme",
+			in:   "password: ${vault:db/password}",
+			errs: []string{`unknown secret scheme "vault"`},
+		},
+		{
+			desc: "missing file",
+			in:   "password: ${file:/does/not/exist}",
+			errs: []string{"unable to resolve ${file:/does/not/exist}"},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := secrets.DefaultResolvers().Expand(context.Background(), tc.in)
+			if err == nil {
+				t.Fatalf("expect expansion to fail")
+			}
+			for _, want := range tc.errs {
+				if !strings.Contains(err.Error(), want) {
+					t.Fatalf("unexpected error: got %q, want substring %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExpandOffline(t *testing.T) {
+	t.Setenv("SECRETS_TEST_USER", "alice")
+
+	in := "user: ${SECRETS_TEST_USER}\npassword: ${secretmanager:projects/my-project/secrets/db-password}"
+	got, err := secrets.DefaultResolvers().Offline().Expand(context.Background(), in)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := "user: alice\npassword: \"${secretmanager:projects/my-project/secrets/db-password}\""; got != want {
+		t.Fatalf("incorrect expansion: got %q, want %q", got, want)
+	}
+
+	// unset variables and unknown schemes are still reported
+	_, err = secrets.DefaultResolvers().Offline().Expand(context.Background(), "user: ${SECRETS_TEST_UNSET}\npassword: ${vault:db/password}")
+	for _, want := range []string{`environment variable "SECRETS_TEST_UNSET" is not set`, `unknown secret scheme "vault"`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Fatalf("unexpected error: got %v, want substring %q", err, want)
+		}
+	}
+}
+
+func TestSecretManagerResolver(t *testing.T) {
+	var gotPath string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if !strings.HasSuffix(r.URL.Path, "/projects/my-project/secrets/db-password/versions/latest:access") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"name":    "projects/my-project/secrets/db-password/versions/1",
+			"payload": map[string]any{"data": base64.StdEncoding.EncodeToString([]byte("s3cret"))},
+		})
+	}))
+	defer ts.Close()
+
+	resolvers := secrets.Resolvers{
+		secrets.SecretManagerScheme: &secrets.SecretManagerResolver{
+			ClientOptions: []option.ClientOption{option.WithEndpoint(ts.URL + "/"), option.WithoutAuthentication()},
+		},
+	}
+
+	got, err := resolvers.Expand(context.Background(), "password: ${secretmanager:projects/my-project/secrets/db-password}")
+	if err != nil {
+		t.Fatalf("unexpected error (path %q): %s", gotPath, err)
+	}
+	if want := "password: s3cret"; got != want {
+		t.Fatalf("incorrect expansion: got %q, want %q", got, want)
+	}
+
+	_, err = resolvers.Expand(context.Background(), "password: ${secretmanager:projects/my-project/secrets/missing}")
+	if err == nil {
+		t.Fatalf("expect missing secret to fail")
+	}
+}
@@ -16,14 +16,17 @@ package server
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
@@ -216,6 +219,14 @@ func toolInvokeHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 	}
 	s.logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
 
+	// forward the caller's access token for sources that run as the end user
+	if token := accessTokenFromHeader(r.Header); token != "" {
+		ctx = util.WithAccessToken(ctx, token)
+	}
+
+	// make verified claims available to tools that apply them at invocation time
+	ctx = util.WithClaims(ctx, claimsFromAuth)
+
 	res, err := tool.Invoke(ctx, params)
 	if err != nil {
 		err = fmt.Errorf("error while invoking tool: %w", err)
@@ -252,12 +263,15 @@ var _ render.Renderer = &errResponse{} // Renderer interface for managing respon
 
 // newErrResponse is a helper function initalizing an ErrResponse
 func newErrResponse(err error, code int) *errResponse {
+	var paramErrs tools.ParamErrors
+	errors.As(err, &paramErrs)
 	return &errResponse{
 		Err:            err,
 		HTTPStatusCode: code,
 
-		StatusText: http.StatusText(code),
-		ErrorText:  err.Error(),
+		StatusText:  http.StatusText(code),
+		ErrorText:   err.Error(),
+		ParamErrors: paramErrs,
 	}
 }
 
@@ -266,8 +280,9 @@ type errResponse struct {
 	Err            error `json:"-"` // low-level runtime error
 	HTTPStatusCode int   `json:"-"` // http response status code
 
-	StatusText string `json:"status"`          // user-level status message
-	ErrorText  string `json:"error,omitempty"` // application-level error message, for debugging
+	StatusText  string            `json:"status"`           // user-level status message
+	ErrorText   string            `json:"error,omitempty"`  // application-level error message, for debugging
+	ParamErrors tools.ParamErrors `json:"errors,omitempty"` // every invalid parameter, when parameters were rejected
 }
 
 func (e *errResponse) Render(w http.ResponseWriter, r *http.Request) error {
@@ -275,6 +290,15 @@ func (e *errResponse) Render(w http.ResponseWriter, r *http.Request) error {
 	return nil
 }
 
+// accessTokenFromHeader returns the bearer token from the Authorization header, if any.
+func accessTokenFromHeader(h http.Header) string {
+	token, ok := strings.CutPrefix(h.Get("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
 // decodeJSON decodes a given reader into an interface using the json decoder.
 func decodeJSON(r io.Reader, v interface{}) error {
 	defer io.Copy(io.Discard, r) //nolint:errcheck
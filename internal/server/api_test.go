@@ -20,10 +20,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/googleapis/genai-toolbox/internal/log"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 )
 
 func TestToolsetEndpoint(t *testing.T) {
@@ -281,3 +285,123 @@ func TestToolInvokeEndpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestToolInvokeEndpointParamErrors(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	r, shutdown := setUpServer(t, "api", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	resp, body, err := runRequest(ts, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tool2.Name), bytes.NewBuffer([]byte(`{"param1": "one"}`)))
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("unexpected status code: want %d, got %d", http.StatusBadRequest, resp.StatusCode)
+	}
+	var got struct {
+		Errors []map[string]any `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unexpected error unmarshalling body: %s", err)
+	}
+	want := []map[string]any{
+		{"param": "param1", "reason": `"one" not type "integer"`},
+		{"param": "param2", "reason": "parameter is required"},
+	}
+	if !reflect.DeepEqual(got.Errors, want) {
+		t.Fatalf("unexpected errors: got %+v, want %+v", got.Errors, want)
+	}
+}
+
+func TestSensitiveParamsNotRecorded(t *testing.T) {
+	email := tools.NewStringParameter("email", "The email of the user.")
+	email.Pattern = "^[^@]+@example[.]com$"
+	email.Sensitive = true
+	tool := MockTool{Name: "sensitive_params", Params: tools.Parameters{email}}
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool})
+
+	var logs bytes.Buffer
+	logger, err := log.NewStdLogger(&logs, &logs, "debug")
+	if err != nil {
+		t.Fatalf("unable to initialize logger: %s", err)
+	}
+	instrumentation, err := CreateTelemetryInstrumentation(fakeVersionString)
+	if err != nil {
+		t.Fatalf("unable to create custom metrics: %s", err)
+	}
+	recorder := tracetest.NewSpanRecorder()
+	instrumentation.Tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer(TracerName)
+	s := &Server{
+		version:         fakeVersionString,
+		logger:          logger,
+		instrumentation: instrumentation,
+		sseManager:      &sseManager{sseSessions: make(map[string]*sseSession)},
+		tools:           toolsMap,
+		toolsets:        toolsets,
+	}
+	apiR, err := apiRouter(s)
+	if err != nil {
+		t.Fatalf("unable to initialize api router: %s", err)
+	}
+	mcpR, err := mcpRouter(s)
+	if err != nil {
+		t.Fatalf("unable to initialize mcp router: %s", err)
+	}
+	apiTs, mcpTs := runServer(apiR, false), runServer(mcpR, false)
+	defer apiTs.Close()
+	defer mcpTs.Close()
+
+	var responses []string
+	for _, value := range []string{"alice@gmail.com", "alice@example.com"} {
+		_, body, err := runRequest(apiTs, http.MethodPost, fmt.Sprintf("/tool/%s/invoke", tool.Name), bytes.NewBufferString(fmt.Sprintf(`{"email": %q}`, value)))
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		responses = append(responses, string(body))
+
+		reqBody := fmt.Sprintf(`{"jsonrpc": "2.0", "id": "tools-call", "method": "tools/call", "params": {"name": %q, "arguments": {"email": %q}}}`, tool.Name, value)
+		_, body, err = runRequest(mcpTs, http.MethodPost, "/", bytes.NewBufferString(reqBody))
+		if err != nil {
+			t.Fatalf("unexpected error during request: %s", err)
+		}
+		responses = append(responses, string(body))
+	}
+	if !strings.Contains(responses[0], `invalid value for sensitive parameter of type \"string\"`) {
+		t.Fatalf("unexpected response: %s", responses[0])
+	}
+	for _, r := range responses {
+		if strings.Contains(r, "alice@") {
+			t.Errorf("response contains the sensitive value: %s", r)
+		}
+	}
+	if !strings.Contains(logs.String(), "invocation params") {
+		t.Fatalf("expected the invocation params to be logged: %s", logs.String())
+	}
+	if strings.Contains(logs.String(), "alice@") {
+		t.Errorf("logs contain the sensitive value: %s", logs.String())
+	}
+
+	spans := recorder.Ended()
+	if len(spans) == 0 || !strings.Contains(spans[0].Status().Description, "invalid value for sensitive parameter") {
+		t.Fatalf("expected the span of the invalid invocation to record its error")
+	}
+	for _, span := range spans {
+		recorded := []string{span.Status().Description}
+		for _, a := range span.Attributes() {
+			recorded = append(recorded, a.Value.Emit())
+		}
+		for _, e := range span.Events() {
+			recorded = append(recorded, e.Name)
+			for _, a := range e.Attributes {
+				recorded = append(recorded, a.Value.Emit())
+			}
+		}
+		for _, r := range recorded {
+			if strings.Contains(r, "alice@") {
+				t.Errorf("span %q records the sensitive value: %s", span.Name(), r)
+			}
+		}
+	}
+}
@@ -20,34 +20,35 @@ import (
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/auth"
-	"github.com/googleapis/genai-toolbox/internal/auth/google"
+	// Register the built-in kinds of auth services, sources and tools.
+	_ "github.com/googleapis/genai-toolbox/internal/auth/google"
 	"github.com/googleapis/genai-toolbox/internal/sources"
-	alloydbpgsrc "github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
-	bigquerysrc "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
-	bigtablesrc "github.com/googleapis/genai-toolbox/internal/sources/bigtable"
-	cloudsqlmssqlsrc "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
-	cloudsqlmysqlsrc "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
-	cloudsqlpgsrc "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
-	dgraphsrc "github.com/googleapis/genai-toolbox/internal/sources/dgraph"
-	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
-	mssqlsrc "github.com/googleapis/genai-toolbox/internal/sources/mssql"
-	mysqlsrc "github.com/googleapis/genai-toolbox/internal/sources/mysql"
-	neo4jsrc "github.com/googleapis/genai-toolbox/internal/sources/neo4j"
-	postgressrc "github.com/googleapis/genai-toolbox/internal/sources/postgres"
-	spannersrc "github.com/googleapis/genai-toolbox/internal/sources/spanner"
-	sqlitesrc "github.com/googleapis/genai-toolbox/internal/sources/sqlite"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/bigtable"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/dgraph"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/http"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/mssql"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/mysql"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/neo4j"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/postgres"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/spanner"
+	_ "github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/tools"
-	"github.com/googleapis/genai-toolbox/internal/tools/alloydbainl"
-	"github.com/googleapis/genai-toolbox/internal/tools/bigquery"
-	"github.com/googleapis/genai-toolbox/internal/tools/bigtable"
-	"github.com/googleapis/genai-toolbox/internal/tools/dgraph"
-	httptool "github.com/googleapis/genai-toolbox/internal/tools/http"
-	"github.com/googleapis/genai-toolbox/internal/tools/mssqlsql"
-	"github.com/googleapis/genai-toolbox/internal/tools/mysqlsql"
-	neo4jtool "github.com/googleapis/genai-toolbox/internal/tools/neo4j"
-	"github.com/googleapis/genai-toolbox/internal/tools/postgressql"
-	"github.com/googleapis/genai-toolbox/internal/tools/spanner"
-	"github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/alloydbainl"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigquery"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/bigtable"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/dgraph"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/http"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/mssqlsql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/mysqlsql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/neo4j"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/postgressql"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/spanner"
+	_ "github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
 	"github.com/googleapis/genai-toolbox/internal/util"
 )
 
@@ -160,94 +161,12 @@ func (c *SourceConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interf
 		if err != nil {
 			return fmt.Errorf("error creating decoder: %w", err)
 		}
-		switch kind {
-		case alloydbpgsrc.SourceKind:
-			actual := alloydbpgsrc.Config{Name: name, IPType: "public"}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case bigtablesrc.SourceKind:
-			actual := bigtablesrc.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case cloudsqlpgsrc.SourceKind:
-			actual := cloudsqlpgsrc.Config{Name: name, IPType: "public"}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case postgressrc.SourceKind:
-			actual := postgressrc.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case cloudsqlmysqlsrc.SourceKind:
-			actual := cloudsqlmysqlsrc.Config{Name: name, IPType: "public"}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case mysqlsrc.SourceKind:
-			actual := mysqlsrc.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case spannersrc.SourceKind:
-			actual := spannersrc.Config{Name: name, Dialect: "googlesql"}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case neo4jsrc.SourceKind:
-			actual := neo4jsrc.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case cloudsqlmssqlsrc.SourceKind:
-			actual := cloudsqlmssqlsrc.Config{Name: name, IPType: "public"}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case mssqlsrc.SourceKind:
-			actual := mssqlsrc.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case dgraphsrc.SourceKind:
-			actual := dgraphsrc.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case httpsrc.SourceKind:
-			actual := httpsrc.DefaultConfig(name)
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case bigquerysrc.SourceKind:
-			actual := bigquerysrc.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case sqlitesrc.SourceKind:
-			actual := sqlitesrc.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		default:
-			return fmt.Errorf("%q is not a valid kind of data source", kind)
+		kindName := fmt.Sprint(kind)
+		actual, err := sources.DecodeConfig(ctx, kindName, name, dec)
+		if err != nil {
+			return err
 		}
+		(*c)[name] = actual
 
 	}
 	return nil
@@ -282,16 +201,12 @@ func (c *AuthServiceConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(i
 		if err != nil {
 			return fmt.Errorf("error creating decoder: %w", err)
 		}
-		switch kind {
-		case google.AuthServiceKind:
-			actual := google.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		default:
-			return fmt.Errorf("%q is not a valid kind of auth source", kind)
+		kindName := fmt.Sprint(kind)
+		actual, err := auth.DecodeConfig(ctx, kindName, name, dec)
+		if err != nil {
+			return err
 		}
+		(*c)[name] = actual
 	}
 	return nil
 }
@@ -310,11 +225,17 @@ func (c *ToolConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interfac
 		return err
 	}
 
+	templates := ToolTemplatesFromContext(ctx)
 	for name, u := range raw {
 		var v map[string]any
 		if err := u.Unmarshal(&v); err != nil {
 			return fmt.Errorf("unable to unmarshal %q: %w", name, err)
 		}
+		// apply templates before the kind is known, since it may be inherited
+		v, err := templates.Resolve(v)
+		if err != nil {
+			return fmt.Errorf("unable to apply templates to %q: %w", name, err)
+		}
 
 		kind, ok := v["kind"]
 		if !ok {
@@ -325,76 +246,12 @@ func (c *ToolConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interfac
 		if err != nil {
 			return fmt.Errorf("error creating decoder: %w", err)
 		}
-		switch kind {
-		case bigtable.ToolKind:
-			actual := bigtable.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case postgressql.ToolKind:
-			actual := postgressql.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case alloydbainl.ToolKind:
-			actual := alloydbainl.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case mysqlsql.ToolKind:
-			actual := mysqlsql.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case spanner.ToolKind:
-			actual := spanner.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case neo4jtool.ToolKind:
-			actual := neo4jtool.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case mssqlsql.ToolKind:
-			actual := mssqlsql.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case dgraph.ToolKind:
-			actual := dgraph.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case httptool.ToolKind:
-			actual := httptool.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case bigquery.ToolKind:
-			actual := bigquery.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		case sqlitesql.ToolKind:
-			actual := sqlitesql.Config{Name: name}
-			if err := dec.DecodeContext(ctx, &actual); err != nil {
-				return fmt.Errorf("unable to parse as %q: %w", kind, err)
-			}
-			(*c)[name] = actual
-		default:
-			return fmt.Errorf("%q is not a valid kind of tool", kind)
+		kindName := fmt.Sprint(kind)
+		actual, err := tools.DecodeConfig(ctx, kindName, name, dec)
+		if err != nil {
+			return err
 		}
+		(*c)[name] = actual
 
 	}
 	return nil
@@ -409,13 +266,21 @@ var _ yaml.InterfaceUnmarshalerContext = &ToolsetConfigs{}
 func (c *ToolsetConfigs) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
 	*c = make(ToolsetConfigs)
 
-	var raw map[string][]string
+	var raw map[string][]any
 	if err := unmarshal(&raw); err != nil {
 		return err
 	}
 
-	for name, toolList := range raw {
-		(*c)[name] = tools.ToolsetConfig{Name: name, ToolNames: toolList}
+	for name, entries := range raw {
+		selectors := make([]tools.ToolSelector, 0, len(entries))
+		for _, e := range entries {
+			sel, err := tools.ParseToolSelector(e)
+			if err != nil {
+				return fmt.Errorf("unable to parse toolset %q: %w", name, err)
+			}
+			selectors = append(selectors, sel)
+		}
+		(*c)[name] = tools.NewToolsetConfig(name, selectors)
 	}
 	return nil
 }
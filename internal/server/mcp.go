@@ -17,6 +17,7 @@ package server
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -27,6 +28,8 @@ import (
 	"github.com/go-chi/render"
 	"github.com/google/uuid"
 	"github.com/googleapis/genai-toolbox/internal/server/mcp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
@@ -339,13 +342,27 @@ func mcpHandler(s *Server, w http.ResponseWriter, r *http.Request) {
 
 		params, err := tool.ParseParams(data, claimsFromAuth)
 		if err != nil {
+			// list every invalid parameter, so that they can be fixed at once
+			var errData any
+			var paramErrs tools.ParamErrors
+			if errors.As(err, &paramErrs) {
+				errData = paramErrs
+			}
 			err = fmt.Errorf("provided parameters were invalid: %w", err)
 			s.logger.DebugContext(ctx, err.Error())
-			res = newJSONRPCError(baseMessage.Id, mcp.INVALID_PARAMS, err.Error(), nil)
+			res = newJSONRPCError(baseMessage.Id, mcp.INVALID_PARAMS, err.Error(), errData)
 			break
 		}
 		s.logger.DebugContext(ctx, fmt.Sprintf("invocation params: %s", params))
 
+		// forward the caller's access token for sources that run as the end user
+		if token := accessTokenFromHeader(r.Header); token != "" {
+			ctx = util.WithAccessToken(ctx, token)
+		}
+
+		// make verified claims available to tools that apply them at invocation time
+		ctx = util.WithClaims(ctx, claimsFromAuth)
+
 		result := mcp.ToolCall(ctx, tool, params)
 		res = mcp.JSONRPCResponse{
 			Jsonrpc: mcp.JSONRPC_VERSION,
@@ -379,3 +379,36 @@ func runSseRequest(ts *httptest.Server, path string, proto string) (*http.Respon
 	}
 	return resp, nil
 }
+
+func TestMcpToolCallParamErrors(t *testing.T) {
+	toolsMap, toolsets := setUpResources(t, []MockTool{tool1, tool2})
+	r, shutdown := setUpServer(t, "mcp", toolsMap, toolsets)
+	defer shutdown()
+	ts := runServer(r, false)
+	defer ts.Close()
+
+	reqBody := `{"jsonrpc": "2.0", "id": "tools-call", "method": "tools/call", "params": {"name": "some_params", "arguments": {"param1": "one"}}}`
+	_, body, err := runRequest(ts, http.MethodPost, "/", bytes.NewBufferString(reqBody))
+	if err != nil {
+		t.Fatalf("unexpected error during request: %s", err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(body, &got); err != nil {
+		t.Fatalf("unexpected error unmarshalling body: %s", err)
+	}
+	want := map[string]any{
+		"jsonrpc": "2.0",
+		"id":      "tools-call",
+		"error": map[string]any{
+			"code":    float64(mcp.INVALID_PARAMS),
+			"message": `provided parameters were invalid: unable to parse value for "param1": "one" not type "integer"; parameter "param2" is required`,
+			"data": []any{
+				map[string]any{"param": "param1", "reason": `"one" not type "integer"`},
+				map[string]any{"param": "param2", "reason": "parameter is required"},
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected response: got %+v, want %+v", got, want)
+	}
+}
@@ -169,6 +169,12 @@ func NewServer(ctx context.Context, cfg ServerConfig, l log.Logger) (*Server, er
 	}
 	l.InfoContext(ctx, fmt.Sprintf("Initialized %d tools.", len(toolsMap)))
 
+	// list the tools chosen by patterns, kinds, sources and included toolsets
+	cfg.ToolsetConfigs, err = tools.ResolveToolsets(cfg.ToolsetConfigs, cfg.ToolConfigs)
+	if err != nil {
+		return nil, err
+	}
+
 	// create a default toolset that contains all tools
 	allToolNames := make([]string, 0, len(toolsMap))
 	for name := range toolsMap {
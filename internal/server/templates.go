@@ -0,0 +1,146 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// extendsField names the template a tool, or another template, inherits from.
+const extendsField = "extends"
+
+// ToolTemplates are partial tool configurations, keyed by name, that tools
+// and other templates inherit from with `extends: <template>`.
+type ToolTemplates map[string]map[string]any
+
+type toolTemplatesKey struct{}
+
+// WithToolTemplates adds the templates available to tools into the context,
+// so that they are applied when ToolConfigs are decoded.
+func WithToolTemplates(ctx context.Context, templates ToolTemplates) context.Context {
+	return context.WithValue(ctx, toolTemplatesKey{}, templates)
+}
+
+// ToolTemplatesFromContext retrieves the templates available to tools, or nil
+// if none are present.
+func ToolTemplatesFromContext(ctx context.Context) ToolTemplates {
+	templates, _ := ctx.Value(toolTemplatesKey{}).(ToolTemplates)
+	return templates
+}
+
+// Resolve returns cfg with the templates it extends merged in. Fields set in
+// cfg override inherited ones; nested mappings are merged recursively and
+// lists of named items, such as parameters, are merged by name.
+func (ts ToolTemplates) Resolve(cfg map[string]any) (map[string]any, error) {
+	return ts.resolve(cfg, nil)
+}
+
+func (ts ToolTemplates) resolve(cfg map[string]any, chain []string) (map[string]any, error) {
+	raw, ok := cfg[extendsField]
+	if !ok {
+		return cfg, nil
+	}
+	name, ok := raw.(string)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("%q must be the name of a template", extendsField)
+	}
+	chain = append(chain, name)
+	if slices.Contains(chain[:len(chain)-1], name) {
+		return nil, fmt.Errorf("templates extend each other in a cycle: %s", strings.Join(chain, " -> "))
+	}
+	template, ok := ts[name]
+	if !ok {
+		return nil, fmt.Errorf("no template named %q configured", name)
+	}
+	base, err := ts.resolve(template, chain)
+	if err != nil {
+		return nil, err
+	}
+	override := maps.Clone(cfg)
+	delete(override, extendsField)
+	return mergeConfig(base, override), nil
+}
+
+// mergeConfig returns base with the fields of override applied on top,
+// without modifying either.
+func mergeConfig(base, override map[string]any) map[string]any {
+	out := maps.Clone(base)
+	if out == nil {
+		out = make(map[string]any, len(override))
+	}
+	for k, v := range override {
+		switch ov := v.(type) {
+		case map[string]any:
+			if bv, ok := out[k].(map[string]any); ok {
+				out[k] = mergeConfig(bv, ov)
+				continue
+			}
+		case []any:
+			if bv, ok := out[k].([]any); ok {
+				if merged, ok := mergeByName(bv, ov); ok {
+					out[k] = merged
+					continue
+				}
+			}
+		}
+		out[k] = v
+	}
+	return out
+}
+
+// mergeByName merges two lists whose items are all mappings with a name, such
+// as parameters. Items of override replace fields of the base item with the
+// same name, and new items are appended. It returns false if either list has
+// an unnamed item, in which case override replaces base as a whole.
+func mergeByName(base, override []any) ([]any, bool) {
+	index := make(map[string]int, len(base))
+	for i, item := range base {
+		name, ok := itemName(item)
+		if !ok {
+			return nil, false
+		}
+		index[name] = i
+	}
+	for _, item := range override {
+		if _, ok := itemName(item); !ok {
+			return nil, false
+		}
+	}
+
+	out := slices.Clone(base)
+	for _, item := range override {
+		name, _ := itemName(item)
+		if i, ok := index[name]; ok {
+			out[i] = mergeConfig(out[i].(map[string]any), item.(map[string]any))
+			continue
+		}
+		index[name] = len(out)
+		out = append(out, item)
+	}
+	return out, true
+}
+
+func itemName(item any) (string, bool) {
+	m, ok := item.(map[string]any)
+	if !ok {
+		return "", false
+	}
+	name, ok := m["name"].(string)
+	return name, ok
+}
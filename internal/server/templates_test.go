@@ -0,0 +1,142 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/server"
+)
+
+func TestResolveToolTemplates(t *testing.T) {
+	templates := server.ToolTemplates{
+		"base": {
+			"kind":         "postgres-sql",
+			"source":       "my-pg",
+			"authRequired": []any{"my-google"},
+			"parameters": []any{
+				map[string]any{"name": "user_id", "type": "string", "description": "The user.", "authServices": []any{map[string]any{"name": "my-google", "field": "sub"}}},
+			},
+		},
+		"admin": {
+			"extends":      "base",
+			"authRequired": []any{"my-admin"},
+		},
+	}
+
+	tcs := []struct {
+		desc string
+		in   map[string]any
+		want map[string]any
+	}{
+		{
+			desc: "no template",
+			in:   map[string]any{"kind": "sqlite-sql"},
+			want: map[string]any{"kind": "sqlite-sql"},
+		},
+		{
+			desc: "inherit and override",
+			in: map[string]any{
+				"extends":     "base",
+				"source":      "other-pg",
+				"description": "List orders.",
+				"parameters": []any{
+					map[string]any{"name": "user_id", "description": "The caller."},
+					map[string]any{"name": "limit", "type": "integer", "description": "Max rows."},
+				},
+			},
+			want: map[string]any{
+				"kind":         "postgres-sql",
+				"source":       "other-pg",
+				"description":  "List orders.",
+				"authRequired": []any{"my-google"},
+				"parameters": []any{
+					map[string]any{"name": "user_id", "type": "string", "description": "The caller.", "authServices": []any{map[string]any{"name": "my-google", "field": "sub"}}},
+					map[string]any{"name": "limit", "type": "integer", "description": "Max rows."},
+				},
+			},
+		},
+		{
+			desc: "chained templates replace unnamed lists",
+			in:   map[string]any{"extends": "admin", "description": "Delete orders."},
+			want: map[string]any{
+				"kind":         "postgres-sql",
+				"source":       "my-pg",
+				"description":  "Delete orders.",
+				"authRequired": []any{"my-admin"},
+				"parameters": []any{
+					map[string]any{"name": "user_id", "type": "string", "description": "The user.", "authServices": []any{map[string]any{"name": "my-google", "field": "sub"}}},
+				},
+			},
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, err := templates.Resolve(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect config (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	// templates must not be modified by resolving
+	if got := templates["base"]["authRequired"]; !cmp.Equal(got, []any{"my-google"}) {
+		t.Fatalf("template was modified: %v", got)
+	}
+}
+
+func TestFailResolveToolTemplates(t *testing.T) {
+	templates := server.ToolTemplates{
+		"a": {"extends": "b"},
+		"b": {"extends": "a"},
+	}
+	tcs := []struct {
+		desc string
+		in   map[string]any
+		err  string
+	}{
+		{
+			desc: "missing template",
+			in:   map[string]any{"extends": "missing"},
+			err:  `no template named "missing" configured`,
+		},
+		{
+			desc: "cycle",
+			in:   map[string]any{"extends": "a"},
+			err:  "templates extend each other in a cycle: a -> b -> a",
+		},
+		{
+			desc: "not a name",
+			in:   map[string]any{"extends": []any{"a"}},
+			err:  `"extends" must be the name of a template`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := templates.Resolve(tc.in)
+			if err == nil {
+				t.Fatalf("expect resolving to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want substring %q", err, tc.err)
+			}
+		})
+	}
+}
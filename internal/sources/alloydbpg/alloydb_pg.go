@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"cloud.google.com/go/alloydbconn"
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -29,6 +30,18 @@ import (
 
 const SourceKind string = "alloydb-postgres"
 
+func init() {
+	sources.Register(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, IPType: "public"}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
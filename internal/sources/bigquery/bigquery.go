@@ -19,15 +19,29 @@ import (
 	"fmt"
 
 	bigqueryapi "cloud.google.com/go/bigquery"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"google.golang.org/api/option"
 
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"go.opentelemetry.io/otel/trace"
 )
 
 const SourceKind string = "bigquery"
 
+func init() {
+	sources.Register(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
@@ -37,6 +51,9 @@ type Config struct {
 	Kind     string `yaml:"kind" validate:"required"`
 	Project  string `yaml:"project" validate:"required"`
 	Location string `yaml:"location"`
+	// UseClientOAuth runs queries with the caller's OAuth access token instead
+	// of the server's Application Default Credentials.
+	UseClientOAuth bool `yaml:"useClientOAuth"`
 }
 
 func (r Config) SourceConfigKind() string {
@@ -46,28 +63,40 @@ func (r Config) SourceConfigKind() string {
 
 func (r Config) Initialize(ctx context.Context, tracer trace.Tracer) (sources.Source, error) {
 	// Initializes a BigQuery Google SQL source
+	s := &Source{
+		Name:           r.Name,
+		Kind:           SourceKind,
+		Location:       r.Location,
+		UseClientOAuth: r.UseClientOAuth,
+		ClientCreator:  NewClientCreator(r.Project, r.Location),
+	}
+	if r.UseClientOAuth {
+		// clients are created per request from the caller's access token
+		return s, nil
+	}
 	client, err := initBigQueryConnection(ctx, tracer, r.Name, r.Project, r.Location)
 	if err != nil {
 		return nil, err
 	}
-	s := &Source{
-		Name:     r.Name,
-		Kind:     SourceKind,
-		Client:   client,
-		Location: r.Location,
-	}
+	s.Client = client
 	return s, nil
 
 }
 
 var _ sources.Source = &Source{}
 
+// ClientCreator creates a BigQuery client that authenticates with the given
+// OAuth access token.
+type ClientCreator func(ctx context.Context, accessToken string) (*bigqueryapi.Client, error)
+
 type Source struct {
 	// BigQuery Google SQL struct with client
-	Name     string `yaml:"name"`
-	Kind     string `yaml:"kind"`
-	Client   *bigqueryapi.Client
-	Location string `yaml:"location"`
+	Name           string `yaml:"name"`
+	Kind           string `yaml:"kind"`
+	Client         *bigqueryapi.Client
+	Location       string `yaml:"location"`
+	UseClientOAuth bool   `yaml:"useClientOAuth"`
+	ClientCreator  ClientCreator
 }
 
 func (s *Source) SourceKind() string {
@@ -79,6 +108,29 @@ func (s *Source) BigQueryClient() *bigqueryapi.Client {
 	return s.Client
 }
 
+func (s *Source) UseClientAuthorization() bool {
+	return s.UseClientOAuth
+}
+
+func (s *Source) BigQueryClientCreator() ClientCreator {
+	return s.ClientCreator
+}
+
+// NewClientCreator returns a ClientCreator for the given project and location.
+// Additional options are applied to every client, e.g. to override the endpoint.
+func NewClientCreator(project, location string, opts ...option.ClientOption) ClientCreator {
+	return func(ctx context.Context, accessToken string) (*bigqueryapi.Client, error) {
+		ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken})
+		clientOpts := append([]option.ClientOption{option.WithTokenSource(ts)}, opts...)
+		client, err := bigqueryapi.NewClient(ctx, project, clientOpts...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create BigQuery client for project %q: %w", project, err)
+		}
+		client.Location = location
+		return client, nil
+	}
+}
+
 func initBigQueryConnection(
 	ctx context.Context,
 	tracer trace.Tracer,
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"cloud.google.com/go/bigtable"
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/api/option"
@@ -26,6 +27,18 @@ import (
 
 const SourceKind string = "bigtable"
 
+func init() {
+	sources.Register(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
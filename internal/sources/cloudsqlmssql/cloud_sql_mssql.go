@@ -21,6 +21,7 @@ import (
 	"slices"
 
 	"cloud.google.com/go/cloudsqlconn/sqlserver/mssql"
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/util"
 	"go.opentelemetry.io/otel/trace"
@@ -28,6 +29,18 @@ import (
 
 const SourceKind string = "cloud-sql-mssql"
 
+func init() {
+	sources.Register(SourceKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (sources.SourceConfig, error) {
+	actual := Config{Name: name, IPType: "public"}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 // validate interface
 var _ sources.SourceConfig = Config{}
 
@@ -16,7 +16,12 @@ package sources
 
 import (
 	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
 
+	yaml "github.com/goccy/go-yaml"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -42,3 +47,43 @@ func InitConnectionSpan(ctx context.Context, tracer trace.Tracer, sourceKind, so
 	)
 	return ctx, span
 }
+
+// SourceConfigFactory decodes the configuration of the data source with the given name.
+type SourceConfigFactory func(ctx context.Context, name string, decoder *yaml.Decoder) (SourceConfig, error)
+
+var sourceRegistry = make(map[string]SourceConfigFactory)
+
+// Register makes a kind of data source available in tools files. It is intended to
+// be called from the init function of the package implementing the kind, and
+// panics if the kind is already registered.
+func Register(kind string, factory SourceConfigFactory) {
+	if _, exists := sourceRegistry[kind]; exists {
+		panic(fmt.Sprintf("sources: kind %q is already registered", kind))
+	}
+	sourceRegistry[kind] = factory
+}
+
+// Kinds returns the registered kinds of data sources in sorted order.
+func Kinds() []string {
+	return slices.Sorted(maps.Keys(sourceRegistry))
+}
+
+// DecodeConfig decodes the configuration of a data source with the factory
+// registered for its kind.
+func DecodeConfig(ctx context.Context, kind, name string, decoder *yaml.Decoder) (SourceConfig, error) {
+	factory, ok := sourceRegistry[kind]
+	if !ok {
+		return nil, fmt.Errorf("%q is not a valid kind of data source, must be one of: %s", kind, strings.Join(Kinds(), ", "))
+	}
+	cfg, err := factory(ctx, name, decoder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse as %q: %w", kind, err)
+	}
+	return cfg, nil
+}
+
+// DefaultConfig returns the configuration of a data source of the given kind with
+// only its defaults set, so that its fields can be inspected.
+func DefaultConfig(kind string) (SourceConfig, error) {
+	return DecodeConfig(context.Background(), kind, "", yaml.NewDecoder(strings.NewReader("{}")))
+}
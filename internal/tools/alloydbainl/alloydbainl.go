@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"strings"
 
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -27,6 +28,18 @@ import (
 
 const ToolKind string = "alloydb-ai-nl"
 
+func init() {
+	tools.Register(ToolKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 type compatibleSource interface {
 	PostgresPool() *pgxpool.Pool
 }
@@ -44,15 +57,26 @@ type Config struct {
 	NLConfig           string           `yaml:"nlConfig" validate:"required"`
 	AuthRequired       []string         `yaml:"authRequired"`
 	NLConfigParameters tools.Parameters `yaml:"nlConfigParameters"`
+	Examples           []map[string]any `yaml:"examples"`
 }
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.ReferencingToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return ToolKind
 }
 
+func (cfg Config) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{
+		Source:                cfg.Source,
+		CompatibleSourceKinds: compatibleSources[:],
+		AuthRequired:          cfg.AuthRequired,
+		Parameters:            cfg.NLConfigParameters,
+	}
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -104,10 +128,16 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 	cfg.NLConfigParameters = append([]tools.Parameter{newQuestionParam}, cfg.NLConfigParameters...)
 
+	if err := tools.CheckExamples(cfg.NLConfigParameters, cfg.Examples); err != nil {
+		return nil, err
+	}
+	inputSchema := cfg.NLConfigParameters.McpManifest()
+	inputSchema.Examples = cfg.Examples
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
-		InputSchema: cfg.NLConfigParameters.McpManifest(),
+		InputSchema: inputSchema,
 	}
 
 	t := Tool{
@@ -118,7 +148,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		NLConfig:     cfg.NLConfig,
 		AuthRequired: cfg.AuthRequired,
 		Pool:         s.PostgresPool(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.NLConfigParameters.Manifest()},
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.NLConfigParameters.Manifest(), Examples: cfg.Examples},
 		mcpManifest:  mcpManifest,
 	}
 
@@ -147,12 +177,16 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 	allParamValues[0] = fmt.Sprintf("%s", sliceParams[0]) // nl_question
 	allParamValues[1] = t.NLConfig                        // nl_config
 	for i, param := range sliceParams[1:] {
-		allParamValues[i+2] = fmt.Sprintf("%s", param)
+		// omitted optional parameters are passed as NULL
+		if param != nil {
+			allParamValues[i+2] = fmt.Sprintf("%s", param)
+		}
 	}
 
 	results, err := t.Pool.Query(ctx, t.Statement, allParamValues...)
 	if err != nil {
-		return nil, fmt.Errorf("unable to execute query: %w. Query: %v , Values: %v", err, t.Statement, allParamValues)
+		// parameters are formatted with sensitive values redacted
+		return nil, fmt.Errorf("unable to execute query: %w. Query: %v , Values: %v", err, t.Statement, params)
 	}
 
 	fields := results.FieldDescriptions()
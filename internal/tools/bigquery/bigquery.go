@@ -18,18 +18,36 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	bigqueryapi "cloud.google.com/go/bigquery"
+	"cloud.google.com/go/civil"
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
 	"google.golang.org/api/iterator"
 )
 
 const ToolKind string = "bigquery-sql"
 
+func init() {
+	tools.Register(ToolKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 type compatibleSource interface {
 	BigQueryClient() *bigqueryapi.Client
+	BigQueryClientCreator() bigqueryds.ClientCreator
+	UseClientAuthorization() bool
 }
 
 // validate compatible sources are still compatible
@@ -45,15 +63,26 @@ type Config struct {
 	Statement    string           `yaml:"statement" validate:"required"`
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
+	Examples     []map[string]any `yaml:"examples"`
 }
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.ReferencingToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return ToolKind
 }
 
+func (cfg Config) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{
+		Source:                cfg.Source,
+		CompatibleSourceKinds: compatibleSources[:],
+		AuthRequired:          cfg.AuthRequired,
+		Parameters:            cfg.Parameters,
+	}
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -67,22 +96,30 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", ToolKind, compatibleSources)
 	}
 
+	if err := tools.CheckExamples(cfg.Parameters, cfg.Examples); err != nil {
+		return nil, err
+	}
+	inputSchema := cfg.Parameters.McpManifest()
+	inputSchema.Examples = cfg.Examples
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
-		InputSchema: cfg.Parameters.McpManifest(),
+		InputSchema: inputSchema,
 	}
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         ToolKind,
-		Parameters:   cfg.Parameters,
-		Statement:    cfg.Statement,
-		AuthRequired: cfg.AuthRequired,
-		Client:       s.BigQueryClient(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest()},
-		mcpManifest:  mcpManifest,
+		Name:           cfg.Name,
+		Kind:           ToolKind,
+		Parameters:     cfg.Parameters,
+		Statement:      cfg.Statement,
+		AuthRequired:   cfg.AuthRequired,
+		UseClientOAuth: s.UseClientAuthorization(),
+		Client:         s.BigQueryClient(),
+		ClientCreator:  s.BigQueryClientCreator(),
+		manifest:       tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), Examples: cfg.Examples},
+		mcpManifest:    mcpManifest,
 	}
 	return t, nil
 }
@@ -96,17 +133,26 @@ type Tool struct {
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
 
-	Client      *bigqueryapi.Client
-	Statement   string
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+	UseClientOAuth bool
+	Client         *bigqueryapi.Client
+	ClientCreator  bigqueryds.ClientCreator
+	Statement      string
+	manifest       tools.Manifest
+	mcpManifest    tools.McpManifest
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
 	namedArgs := make([]bigqueryapi.QueryParameter, 0, len(params))
-	paramsMap := params.AsReversedMap()
-	for _, v := range params.AsSlice() {
-		paramName := paramsMap[v]
+	for i, p := range params {
+		paramName, v := p.Name, p.Value
+		if v == nil {
+			v = nullValue(t.Parameters[i])
+		}
+		if obj, ok := t.Parameters[i].(*tools.ObjectParameter); ok {
+			structV := typedValue(obj, p.Value)
+			v = &structV
+		}
+		v = nativeValue(t.Parameters[i], v)
 		if strings.Contains(t.Statement, "@"+paramName) {
 			namedArgs = append(namedArgs, bigqueryapi.QueryParameter{
 				Name:  paramName,
@@ -119,7 +165,21 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 		}
 	}
 
-	query := t.Client.Query(t.Statement)
+	client := t.Client
+	if t.UseClientOAuth {
+		// run the query as the caller instead of the server's identity
+		accessToken, err := util.AccessTokenFromContext(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("tool requires an OAuth access token in the Authorization header: %w", err)
+		}
+		client, err = t.ClientCreator(ctx, accessToken)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create client from access token: %w", err)
+		}
+		defer client.Close()
+	}
+
+	query := client.Query(t.Statement)
 	query.Parameters = namedArgs
 
 	it, err := query.Read(ctx)
@@ -147,6 +207,112 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 	return out, nil
 }
 
+// nullValue returns a typed NULL for an omitted optional parameter, since
+// BigQuery cannot infer the type of an untyped one.
+func nullValue(p tools.Parameter) any {
+	switch p.GetType() {
+	case "string":
+		return bigqueryapi.NullString{}
+	case "integer":
+		return bigqueryapi.NullInt64{}
+	case "float":
+		return bigqueryapi.NullFloat64{}
+	case "boolean":
+		return bigqueryapi.NullBool{}
+	case "date":
+		return bigqueryapi.NullDate{}
+	case "timestamp":
+		return bigqueryapi.NullTimestamp{}
+	case "duration":
+		// there is no null type for intervals, so the type is given explicitly
+		return &bigqueryapi.QueryParameterValue{
+			Type:  bigqueryapi.StandardSQLDataType{TypeKind: "INTERVAL"},
+			Value: bigqueryapi.NullString{},
+		}
+	case "array":
+		if a, ok := p.(*tools.ArrayParameter); ok {
+			switch a.Items.GetType() {
+			case "string":
+				return []string(nil)
+			case "integer":
+				return []int64(nil)
+			case "float":
+				return []float64(nil)
+			case "boolean":
+				return []bool(nil)
+			}
+		}
+	}
+	return nil
+}
+
+// typedValue returns "v" with the BigQuery type of the parameter "p", so that
+// objects can be bound as STRUCTs.
+func typedValue(p tools.Parameter, v any) bigqueryapi.QueryParameterValue {
+	switch p := p.(type) {
+	case *tools.ObjectParameter:
+		obj, _ := v.(map[string]any)
+		rtn := bigqueryapi.QueryParameterValue{
+			Type:        bigqueryapi.StandardSQLDataType{TypeKind: "STRUCT", StructType: &bigqueryapi.StandardSQLStructType{}},
+			StructValue: make(map[string]bigqueryapi.QueryParameterValue),
+		}
+		for _, name := range p.PropertyNames(obj) {
+			field := typedValue(p.Property(name), obj[name])
+			rtn.Type.StructType.Fields = append(rtn.Type.StructType.Fields, &bigqueryapi.StandardSQLField{Name: name, Type: &field.Type})
+			rtn.StructValue[name] = field
+		}
+		if obj == nil {
+			// a struct without a value is sent as NULL
+			rtn.StructValue = nil
+			rtn.Value = bigqueryapi.NullString{}
+		}
+		return rtn
+	case *tools.ArrayParameter:
+		items, _ := v.([]any)
+		elem := typedValue(p.Items, nil).Type
+		rtn := bigqueryapi.QueryParameterValue{
+			Type: bigqueryapi.StandardSQLDataType{TypeKind: "ARRAY", ArrayElementType: &elem},
+		}
+		for _, item := range items {
+			rtn.ArrayValue = append(rtn.ArrayValue, typedValue(p.Items, item))
+		}
+		if len(items) == 0 {
+			rtn.Value = []any{}
+		}
+		return rtn
+	}
+	if v == nil {
+		v = nullValue(p)
+	}
+	kinds := map[string]string{
+		"string":    "STRING",
+		"integer":   "INT64",
+		"float":     "FLOAT64",
+		"boolean":   "BOOL",
+		"date":      "DATE",
+		"timestamp": "TIMESTAMP",
+		"duration":  "INTERVAL",
+	}
+	return bigqueryapi.QueryParameterValue{
+		Type:  bigqueryapi.StandardSQLDataType{TypeKind: kinds[p.GetType()]},
+		Value: nativeValue(p, v),
+	}
+}
+
+// nativeValue converts the value of a date or duration parameter to the type
+// BigQuery binds it as.
+func nativeValue(p tools.Parameter, v any) any {
+	switch v := v.(type) {
+	case time.Time:
+		if p.GetType() == "date" {
+			return civil.DateOf(v)
+		}
+	case time.Duration:
+		return bigqueryapi.IntervalValueFromDuration(v)
+	}
+	return v
+}
+
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
 	return tools.ParseParams(t.Parameters, data, claims)
 }
@@ -15,14 +15,23 @@
 package bigquery_test
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	bigqueryds "github.com/googleapis/genai-toolbox/internal/sources/bigquery"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/tools/bigquery"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"google.golang.org/api/option"
 )
 
 func TestParseFromYamlSpanner(t *testing.T) {
@@ -81,3 +90,143 @@ func TestParseFromYamlSpanner(t *testing.T) {
 	}
 
 }
+
+// newFakeBigQueryServer returns a server that answers jobs.query requests with
+// a single row containing the bearer token it was called with. The body of
+// each request is decoded into requests, if set.
+func newFakeBigQueryServer(t *testing.T, requests *[]map[string]any) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/projects/my-project/queries") {
+			t.Errorf("unexpected request path: %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		if requests != nil {
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("unable to decode request: %s", err)
+			}
+			*requests = append(*requests, body)
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		resp := map[string]any{
+			"kind":         "bigquery#queryResponse",
+			"jobComplete":  true,
+			"jobReference": map[string]any{"projectId": "my-project", "jobId": "job-1"},
+			"schema": map[string]any{
+				"fields": []map[string]any{{"name": "token", "type": "STRING"}},
+			},
+			"rows":      []map[string]any{{"f": []map[string]any{{"v": token}}}},
+			"totalRows": "1",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}))
+}
+
+func TestInvokeWithClientOAuth(t *testing.T) {
+	ts := newFakeBigQueryServer(t, nil)
+	defer ts.Close()
+
+	src := &bigqueryds.Source{
+		Name:           "my-instance",
+		Kind:           bigqueryds.SourceKind,
+		UseClientOAuth: true,
+		ClientCreator:  bigqueryds.NewClientCreator("my-project", "us", option.WithEndpoint(ts.URL+"/")),
+	}
+	cfg := bigquery.Config{
+		Name:        "example_tool",
+		Kind:        bigquery.ToolKind,
+		Source:      "my-instance",
+		Description: "some description",
+		Statement:   "SELECT SESSION_USER() AS token;",
+		Parameters:  tools.Parameters{},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	t.Run("uses caller token", func(t *testing.T) {
+		ctx := util.WithAccessToken(context.Background(), "user-token")
+		got, err := tool.Invoke(ctx, tools.ParamValues{})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		want := []any{map[string]any{"token": "user-token"}}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("incorrect result: diff %v", diff)
+		}
+	})
+
+	t.Run("missing token", func(t *testing.T) {
+		_, err := tool.Invoke(context.Background(), tools.ParamValues{})
+		if err == nil {
+			t.Fatalf("expected error when no access token is provided")
+		}
+	})
+}
+
+func TestInvokeWithObjectParameter(t *testing.T) {
+	var requests []map[string]any
+	ts := newFakeBigQueryServer(t, &requests)
+	defer ts.Close()
+
+	src := &bigqueryds.Source{
+		Name:           "my-instance",
+		Kind:           bigqueryds.SourceKind,
+		UseClientOAuth: true,
+		ClientCreator:  bigqueryds.NewClientCreator("my-project", "us", option.WithEndpoint(ts.URL+"/")),
+	}
+	cfg := bigquery.Config{
+		Name:        "example_tool",
+		Kind:        bigquery.ToolKind,
+		Source:      "my-instance",
+		Description: "some description",
+		Statement:   "SELECT @filter.name AS token;",
+		Parameters: tools.Parameters{
+			tools.NewObjectParameter("filter", "some description", tools.Parameters{
+				tools.NewStringParameter("name", "some description"),
+				tools.NewArrayParameter("ids", "some description", tools.NewIntParameter("id", "some description")),
+				tools.NewBooleanParameterWithRequired("active", "some description", false),
+			}),
+		},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-instance": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"filter": map[string]any{"name": "a", "ids": []any{1, 2}}}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	ctx := util.WithAccessToken(context.Background(), "user-token")
+	if _, err := tool.Invoke(ctx, params); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("unexpected requests: %v", requests)
+	}
+	want := []any{map[string]any{
+		"name": "filter",
+		"parameterType": map[string]any{
+			"type": "STRUCT",
+			"structTypes": []any{
+				map[string]any{"name": "name", "type": map[string]any{"type": "STRING"}},
+				map[string]any{"name": "ids", "type": map[string]any{"type": "ARRAY", "arrayType": map[string]any{"type": "INT64"}}},
+				map[string]any{"name": "active", "type": map[string]any{"type": "BOOL"}},
+			},
+		},
+		"parameterValue": map[string]any{
+			"structValues": map[string]any{
+				"name":   map[string]any{"value": "a"},
+				"ids":    map[string]any{"arrayValues": []any{map[string]any{"value": "1"}, map[string]any{"value": "2"}}},
+				"active": map[string]any{"value": nil},
+			},
+		},
+	}}
+	if diff := cmp.Diff(want, requests[0]["queryParameters"]); diff != "" {
+		t.Fatalf("incorrect query parameters (-want +got):\n%s", diff)
+	}
+}
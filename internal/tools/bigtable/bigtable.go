@@ -17,8 +17,11 @@ package bigtable
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"cloud.google.com/go/bigtable"
+	"cloud.google.com/go/civil"
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	bigtabledb "github.com/googleapis/genai-toolbox/internal/sources/bigtable"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -26,6 +29,18 @@ import (
 
 const ToolKind string = "bigtable-sql"
 
+func init() {
+	tools.Register(ToolKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 type compatibleSource interface {
 	BigtableClient() *bigtable.Client
 }
@@ -43,15 +58,26 @@ type Config struct {
 	Statement    string           `yaml:"statement" validate:"required"`
 	AuthRequired []string         `yaml:"authRequired"`
 	Parameters   tools.Parameters `yaml:"parameters"`
+	Examples     []map[string]any `yaml:"examples"`
 }
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.ReferencingToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return ToolKind
 }
 
+func (cfg Config) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{
+		Source:                cfg.Source,
+		CompatibleSourceKinds: compatibleSources[:],
+		AuthRequired:          cfg.AuthRequired,
+		Parameters:            cfg.Parameters,
+	}
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -65,10 +91,25 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", ToolKind, compatibleSources)
 	}
 
+	paramTypes := make(map[string]bigtable.SQLType, len(cfg.Parameters))
+	for _, p := range cfg.Parameters {
+		typ, err := sqlType(p)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", p.GetName(), err)
+		}
+		paramTypes[p.GetName()] = typ
+	}
+
+	if err := tools.CheckExamples(cfg.Parameters, cfg.Examples); err != nil {
+		return nil, err
+	}
+	inputSchema := cfg.Parameters.McpManifest()
+	inputSchema.Examples = cfg.Examples
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
-		InputSchema: cfg.Parameters.McpManifest(),
+		InputSchema: inputSchema,
 	}
 
 	// finish tool setup
@@ -79,7 +120,8 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Statement:    cfg.Statement,
 		AuthRequired: cfg.AuthRequired,
 		Client:       s.BigtableClient(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest()},
+		paramTypes:   paramTypes,
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), Examples: cfg.Examples},
 		mcpManifest:  mcpManifest,
 	}
 	return t, nil
@@ -96,51 +138,81 @@ type Tool struct {
 
 	Client      *bigtable.Client
 	Statement   string
+	paramTypes  map[string]bigtable.SQLType
 	manifest    tools.Manifest
 	mcpManifest tools.McpManifest
 }
 
-func getMapParamsType(tparams tools.Parameters, params tools.ParamValues) (map[string]bigtable.SQLType, error) {
-	paramTypeMap := make(map[string]string)
-	for _, p := range tparams {
-		paramTypeMap[p.GetName()] = p.GetType()
+// sqlType returns the SQL type the values of "p" are bound as. Bigtable has no
+// interval type to bind durations as, and doesn't accept structs or maps as
+// parameters, so duration and object parameters are rejected.
+func sqlType(p tools.Parameter) (bigtable.SQLType, error) {
+	switch p.GetType() {
+	case "boolean":
+		return bigtable.BoolSQLType{}, nil
+	case "string":
+		return bigtable.StringSQLType{}, nil
+	case "integer":
+		return bigtable.Int64SQLType{}, nil
+	case "float":
+		return bigtable.Float64SQLType{}, nil
+	case "date":
+		return bigtable.DateSQLType{}, nil
+	case "timestamp":
+		return bigtable.TimestampSQLType{}, nil
+	case "array":
+		a, ok := p.(*tools.ArrayParameter)
+		if !ok {
+			break
+		}
+		if a.Items.GetType() == "array" {
+			return nil, fmt.Errorf("%q tools do not support arrays of arrays", ToolKind)
+		}
+		elem, err := sqlType(a.Items)
+		if err != nil {
+			return nil, err
+		}
+		return bigtable.ArraySQLType{ElemType: elem}, nil
 	}
+	return nil, fmt.Errorf("%q tools do not support %s parameters", ToolKind, p.GetType())
+}
 
-	btParams := make(map[string]bigtable.SQLType)
-	for _, p := range params {
-		switch paramTypeMap[p.Name] {
-		case "boolean":
-			btParams[p.Name] = bigtable.BoolSQLType{}
-		case "string":
-			btParams[p.Name] = bigtable.StringSQLType{}
-		case "integer":
-			btParams[p.Name] = bigtable.Int64SQLType{}
-		case "float":
-			btParams[p.Name] = bigtable.Float64SQLType{}
-		case "array":
-			btParams[p.Name] = bigtable.ArraySQLType{}
+// bindValue converts "v" to the Go type the client binds values of "typ" as.
+// Nil, for null and omitted optional parameters, is bound as a NULL of "typ".
+func bindValue(typ bigtable.SQLType, v any) any {
+	switch typ := typ.(type) {
+	case bigtable.DateSQLType:
+		// dates are parsed as a time.Time at midnight UTC
+		if d, ok := v.(time.Time); ok {
+			return civil.DateOf(d)
+		}
+	case bigtable.ArraySQLType:
+		if l, ok := v.([]any); ok {
+			out := make([]any, len(l))
+			for i, item := range l {
+				out[i] = bindValue(typ.ElemType, item)
+			}
+			return out
 		}
 	}
-
-	return btParams, nil
+	return v
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
-	mapParamsType, err := getMapParamsType(t.Parameters, params)
-	if err != nil {
-		return nil, fmt.Errorf("fail to get map params: %w", err)
-	}
-
 	ps, err := t.Client.PrepareStatement(
 		ctx,
 		t.Statement,
-		mapParamsType,
+		t.paramTypes,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to prepare statement: %w", err)
 	}
 
-	bs, err := ps.Bind(params.AsMap())
+	values := params.AsMap()
+	for name, v := range values {
+		values[name] = bindValue(t.paramTypes[name], v)
+	}
+	bs, err := ps.Bind(values)
 	if err != nil {
 		return nil, fmt.Errorf("unable to bind: %w", err)
 	}
@@ -15,14 +15,25 @@
 package bigtable_test
 
 import (
+	"context"
+	"net"
 	"testing"
+	"time"
 
+	bigtableapi "cloud.google.com/go/bigtable"
+	btpb "cloud.google.com/go/bigtable/apiv2/bigtablepb"
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/tools/bigtable"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestParseFromYamlBigtable(t *testing.T) {
@@ -81,3 +92,177 @@ func TestParseFromYamlBigtable(t *testing.T) {
 	}
 
 }
+
+// fakeSource is a Bigtable source with the given client, which is nil for
+// tools that are never invoked.
+type fakeSource struct {
+	client *bigtableapi.Client
+}
+
+func (fakeSource) SourceKind() string                    { return "bigtable" }
+func (s fakeSource) BigtableClient() *bigtableapi.Client { return s.client }
+
+func TestFailInitializeBigtable(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc  string
+		param string
+		err   string
+	}{
+		{
+			desc: "object",
+			param: `
+- name: address
+  type: object
+  description: The address.
+  properties:
+    - name: city
+      type: string
+      description: The city.
+`,
+			err: `parameter "address": "bigtable-sql" tools do not support object parameters`,
+		},
+		{
+			desc: "duration",
+			param: `
+- name: age
+  type: duration
+  description: The maximum age.
+`,
+			err: `parameter "age": "bigtable-sql" tools do not support duration parameters`,
+		},
+		{
+			desc: "array of objects",
+			param: `
+- name: addresses
+  type: array
+  description: The addresses.
+  items:
+    name: address
+    type: object
+    description: An address.
+    additionalProperties:
+      name: value
+      type: string
+      description: A value.
+`,
+			err: `parameter "addresses": "bigtable-sql" tools do not support object parameters`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var params tools.Parameters
+			if err := yaml.UnmarshalContext(ctx, []byte(tc.param), &params); err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			cfg := bigtable.Config{
+				Name:        "example_tool",
+				Kind:        bigtable.ToolKind,
+				Source:      "my-instance",
+				Description: "some description",
+				Statement:   "SELECT * FROM t",
+				Parameters:  params,
+			}
+			_, err := cfg.Initialize(map[string]sources.Source{"my-instance": fakeSource{}})
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+// queryServer is a Bigtable server that records the parameters a query is
+// executed with and returns no rows, since the emulator doesn't support
+// queries.
+type queryServer struct {
+	btpb.UnimplementedBigtableServer
+	params map[string]*btpb.Value
+}
+
+func (s *queryServer) PrepareQuery(_ context.Context, req *btpb.PrepareQueryRequest) (*btpb.PrepareQueryResponse, error) {
+	return &btpb.PrepareQueryResponse{
+		Metadata: &btpb.ResultSetMetadata{
+			Schema: &btpb.ResultSetMetadata_ProtoSchema{ProtoSchema: &btpb.ProtoSchema{}},
+		},
+		PreparedQuery: []byte(req.Query),
+		ValidUntil:    timestamppb.New(time.Now().Add(time.Hour)),
+	}, nil
+}
+
+func (s *queryServer) ExecuteQuery(req *btpb.ExecuteQueryRequest, _ btpb.Bigtable_ExecuteQueryServer) error {
+	s.params = req.Params
+	return nil
+}
+
+func TestInvokeBindsTypedNulls(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	srv := &queryServer{}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unable to listen: %s", err)
+	}
+	gs := grpc.NewServer()
+	btpb.RegisterBigtableServer(gs, srv)
+	go func() { _ = gs.Serve(lis) }()
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("unable to connect: %s", err)
+	}
+	client, err := bigtableapi.NewClientWithConfig(ctx, "my-project", "my-instance",
+		bigtableapi.ClientConfig{MetricsProvider: bigtableapi.NoopMetricsProvider{}}, option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("unable to create client: %s", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	in := `
+- name: city
+  type: string
+  description: The city, or null for every city.
+  nullable: true
+- name: limit
+  type: integer
+  description: The maximum number of rows.
+  required: false
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	cfg := bigtable.Config{
+		Name:        "example_tool",
+		Kind:        bigtable.ToolKind,
+		Source:      "my-instance",
+		Description: "some description",
+		Statement:   "SELECT * FROM t WHERE @city IS NULL LIMIT @limit",
+		Parameters:  params,
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-instance": fakeSource{client: client}})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	paramValues, err := tool.ParseParams(map[string]any{"city": nil}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.Invoke(ctx, paramValues); err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+
+	// a NULL has a type but no value
+	want := map[string]*btpb.Value{
+		"city":  {Type: &btpb.Type{Kind: &btpb.Type_StringType{StringType: &btpb.Type_String{}}}},
+		"limit": {Type: &btpb.Type{Kind: &btpb.Type_Int64Type{Int64Type: &btpb.Type_Int64{}}}},
+	}
+	if diff := cmp.Diff(want, srv.params, protocmp.Transform()); diff != "" {
+		t.Fatalf("incorrect params (-want +got):\n%s", diff)
+	}
+}
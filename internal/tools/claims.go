@@ -0,0 +1,199 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// claimTransforms are the transforms that can be applied to string claims,
+// by the name they are configured with.
+var claimTransforms = map[string]func(string) string{
+	"lowercase": strings.ToLower,
+	"uppercase": strings.ToUpper,
+	"trimSpace": strings.TrimSpace,
+	// trimDomain keeps the local part of an email address
+	"trimDomain": func(s string) string {
+		if i := strings.LastIndex(s, "@"); i >= 0 {
+			return s[:i]
+		}
+		return s
+	},
+	// domain keeps the domain of an email address
+	"domain": func(s string) string {
+		if i := strings.LastIndex(s, "@"); i >= 0 {
+			return s[i+1:]
+		}
+		return s
+	},
+}
+
+// check validates the field path and the transforms of the auth service.
+func (a ParamAuthService) check() error {
+	if _, err := parseClaimPath(a.Field); err != nil {
+		return err
+	}
+	for _, t := range a.Transforms {
+		if _, ok := claimTransforms[t]; !ok {
+			return fmt.Errorf("unknown transform %q", t)
+		}
+	}
+	return nil
+}
+
+// claim returns the transformed value of the field of the auth service in
+// "claims". Numbers are returned as a json.Number, like numbers in a request
+// body.
+func (a ParamAuthService) claim(claims map[string]any) (any, error) {
+	v, ok := claims[a.Field]
+	if !ok {
+		// fields naming a top-level claim, which may contain dots, take
+		// precedence over paths into nested claims
+		path, err := parseClaimPath(a.Field)
+		if err != nil {
+			return nil, err
+		}
+		if v, ok = lookupClaim(claims, path); !ok {
+			return nil, fmt.Errorf("no field named %s in claims", a.Field)
+		}
+	}
+	v = claimValue(v)
+	for _, t := range a.Transforms {
+		var err error
+		if v, err = transformClaim(v, t, claimTransforms[t]); err != nil {
+			return nil, fmt.Errorf("unable to transform claim %q: %w", a.Field, err)
+		}
+	}
+	return v, nil
+}
+
+// parseClaimPath splits a path such as "address.country" or "groups[0]" into
+// the keys and indexes of its segments.
+func parseClaimPath(field string) ([]any, error) {
+	var path []any
+	for _, segment := range strings.Split(field, ".") {
+		key, rest, _ := strings.Cut(segment, "[")
+		if key == "" {
+			return nil, fmt.Errorf("invalid claim path %q", field)
+		}
+		path = append(path, key)
+		for rest != "" {
+			idx, after, ok := strings.Cut(rest, "]")
+			i, err := strconv.Atoi(idx)
+			if !ok || err != nil || i < 0 || (after != "" && after[0] != '[') {
+				return nil, fmt.Errorf("invalid claim path %q", field)
+			}
+			path = append(path, i)
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return path, nil
+}
+
+func lookupClaim(claims map[string]any, path []any) (any, bool) {
+	var v any = claims
+	for _, segment := range path {
+		switch segment := segment.(type) {
+		case string:
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[segment]; !ok {
+				return nil, false
+			}
+		case int:
+			l, ok := v.([]any)
+			if !ok || segment >= len(l) {
+				return nil, false
+			}
+			v = l[segment]
+		}
+	}
+	return v, true
+}
+
+// claimValue replaces the numbers of a decoded claim with a json.Number.
+func claimValue(v any) any {
+	switch v := v.(type) {
+	case float64:
+		return json.Number(strconv.FormatFloat(v, 'f', -1, 64))
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			out[i] = claimValue(item)
+		}
+		return out
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, item := range v {
+			out[k] = claimValue(item)
+		}
+		return out
+	}
+	return v
+}
+
+// transformClaim applies a transform to a string claim, or to each item of an
+// array of strings.
+func transformClaim(v any, name string, transform func(string) string) (any, error) {
+	switch v := v.(type) {
+	case string:
+		return transform(v), nil
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			var err error
+			if out[i], err = transformClaim(item, name, transform); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return nil, fmt.Errorf("transform %q only applies to strings, not to a %s", name, jsonType(v))
+}
+
+// claimParseError explains why the claim of the auth service "a" could not be
+// parsed as the parameter "p".
+func claimParseError(p Parameter, a ParamAuthService, err error) error {
+	var te *ParseTypeError
+	if errors.As(err, &te) {
+		return fmt.Errorf("claim %q of auth service %q has a value of JSON type %s where parameter %q expects type %q", a.Field, a.Name, jsonType(te.Value), p.GetName(), te.Type)
+	}
+	return fmt.Errorf("claim %q of auth service %q: %w", a.Field, a.Name, err)
+}
+
+// jsonType returns the JSON type of a decoded value.
+func jsonType(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case json.Number, float64, int:
+		return "number"
+	case bool:
+		return "boolean"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return fmt.Sprintf("%T", v)
+}
@@ -0,0 +1,271 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+// idTokenClaims are claims as decoded from an ID token.
+const idTokenClaims = `{
+	"sub": "1234",
+	"email": "Alice@Example.com",
+	"https://example.com/tenant": "acme",
+	"address": {"country": "CH", "zip": 8001},
+	"groups": ["Admins", "Users"],
+	"role": "Viewer",
+	"age": 42
+}`
+
+func TestClaimParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal([]byte(idTokenClaims), &claims); err != nil {
+		t.Fatalf("unable to decode claims: %s", err)
+	}
+	claimsMap := map[string]map[string]any{"my-auth": claims}
+
+	tcs := []struct {
+		desc string
+		in   string
+		want any
+	}{
+		{
+			desc: "top-level claim with dots",
+			in: `
+name: tenant
+type: string
+description: The tenant.
+authServices:
+  - name: my-auth
+    field: https://example.com/tenant
+`,
+			want: "acme",
+		},
+		{
+			desc: "nested claim",
+			in: `
+name: country
+type: string
+description: The country.
+authServices:
+  - name: my-auth
+    field: address.country
+`,
+			want: "CH",
+		},
+		{
+			desc: "array index",
+			in: `
+name: group
+type: string
+description: The first group.
+authServices:
+  - name: my-auth
+    field: groups[0]
+`,
+			want: "Admins",
+		},
+		{
+			desc: "number claim",
+			in: `
+name: zip
+type: integer
+description: The zip code.
+authServices:
+  - name: my-auth
+    field: address.zip
+`,
+			want: 8001,
+		},
+		{
+			desc: "array claim",
+			in: `
+name: groups
+type: array
+description: The groups.
+items:
+  name: group
+  type: string
+  description: A group.
+authServices:
+  - name: my-auth
+    field: groups
+    transforms: [lowercase]
+`,
+			want: []any{"admins", "users"},
+		},
+		{
+			desc: "single value array claim",
+			in: `
+name: roles
+type: array
+description: The roles.
+items:
+  name: role
+  type: string
+  description: A role.
+authServices:
+  - name: my-auth
+    field: role
+`,
+			want: []any{"Viewer"},
+		},
+		{
+			desc: "transforms",
+			in: `
+name: user
+type: string
+description: The user.
+authServices:
+  - name: my-auth
+    field: email
+    transforms: [trimDomain, lowercase]
+`,
+			want: "alice",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var params tools.Parameters
+			if err := yaml.UnmarshalContext(ctx, []byte("- "+strings.ReplaceAll(strings.TrimSpace(tc.in), "\n", "\n  ")), &params); err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			got, err := tools.ParseParams(params, nil, claimsMap)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got[0].Value); diff != "" {
+				t.Fatalf("incorrect value: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailClaimParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	var claims map[string]any
+	if err := json.Unmarshal([]byte(idTokenClaims), &claims); err != nil {
+		t.Fatalf("unable to decode claims: %s", err)
+	}
+	claimsMap := map[string]map[string]any{"my-auth": claims}
+
+	tcs := []struct {
+		desc  string
+		field string
+		typ   string
+		err   string
+	}{
+		{
+			desc:  "missing nested claim",
+			field: "address.city",
+			typ:   "string",
+			err:   "no field named address.city in claims",
+		},
+		{
+			desc:  "index out of range",
+			field: "groups[2]",
+			typ:   "string",
+			err:   "no field named groups[2] in claims",
+		},
+		{
+			desc:  "type mismatch",
+			field: "age",
+			typ:   "string",
+			err:   `claim "age" of auth service "my-auth" has a value of JSON type number where parameter "p" expects type "string"`,
+		},
+		{
+			desc:  "object claim",
+			field: "address",
+			typ:   "integer",
+			err:   `claim "address" of auth service "my-auth" has a value of JSON type object where parameter "p" expects type "integer"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			in := `
+- name: p
+  type: ` + tc.typ + `
+  description: A parameter.
+  authServices:
+    - name: my-auth
+      field: ` + tc.field
+			var params tools.Parameters
+			if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+				t.Fatalf("unable to unmarshal: %s", err)
+			}
+			_, err := tools.ParseParams(params, nil, claimsMap)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestFailClaimParametersUnmarshal(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		desc string
+		auth string
+		err  string
+	}{
+		{
+			desc: "invalid path",
+			auth: "{name: my-auth, field: \"groups[x]\"}",
+			err:  `invalid auth service "my-auth" for parameter "p": invalid claim path "groups[x]"`,
+		},
+		{
+			desc: "empty segment",
+			auth: "{name: my-auth, field: address..country}",
+			err:  `invalid claim path "address..country"`,
+		},
+		{
+			desc: "unknown transform",
+			auth: "{name: my-auth, field: email, transforms: [reverse]}",
+			err:  `unknown transform "reverse"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			in := `
+- name: p
+  type: string
+  description: A parameter.
+  authServices:
+    - ` + tc.auth
+			var params tools.Parameters
+			err := yaml.UnmarshalContext(ctx, []byte(in), &params)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
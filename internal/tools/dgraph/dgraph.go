@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/dgraph"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -26,6 +27,18 @@ import (
 
 const ToolKind string = "dgraph-dql"
 
+func init() {
+	tools.Register(ToolKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 type compatibleSource interface {
 	DgraphClient() *dgraph.DgraphClient
 }
@@ -45,15 +58,26 @@ type Config struct {
 	IsQuery      bool             `yaml:"isQuery"`
 	Timeout      string           `yaml:"timeout"`
 	Parameters   tools.Parameters `yaml:"parameters"`
+	Examples     []map[string]any `yaml:"examples"`
 }
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.ReferencingToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return ToolKind
 }
 
+func (cfg Config) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{
+		Source:                cfg.Source,
+		CompatibleSourceKinds: compatibleSources[:],
+		AuthRequired:          cfg.AuthRequired,
+		Parameters:            cfg.Parameters,
+	}
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -67,10 +91,16 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", ToolKind, compatibleSources)
 	}
 
+	if err := tools.CheckExamples(cfg.Parameters, cfg.Examples); err != nil {
+		return nil, err
+	}
+	inputSchema := cfg.Parameters.McpManifest()
+	inputSchema.Examples = cfg.Examples
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
-		InputSchema: cfg.Parameters.McpManifest(),
+		InputSchema: inputSchema,
 	}
 
 	// finish tool setup
@@ -83,7 +113,7 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		DgraphClient: s.DgraphClient(),
 		IsQuery:      cfg.IsQuery,
 		Timeout:      cfg.Timeout,
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest()},
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest(), Examples: cfg.Examples},
 		mcpManifest:  mcpManifest,
 	}
 	return t, nil
@@ -106,7 +136,15 @@ type Tool struct {
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
-	paramsMap := params.AsMapWithDollarPrefix()
+	// omitted optional parameters are left out, so that the defaults declared
+	// in the query apply
+	given := make(tools.ParamValues, 0, len(params))
+	for _, p := range params {
+		if p.Value != nil {
+			given = append(given, p)
+		}
+	}
+	paramsMap := given.AsMapWithDollarPrefix()
 
 	resp, err := t.DgraphClient.ExecuteQuery(t.Statement, paramsMap, t.IsQuery, t.Timeout)
 	if err != nil {
@@ -27,6 +27,7 @@ import (
 	"maps"
 	"text/template"
 
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -34,6 +35,18 @@ import (
 
 const ToolKind string = "http"
 
+func init() {
+	tools.Register(ToolKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 type Config struct {
 	Name         string            `yaml:"name" validate:"required"`
 	Kind         string            `yaml:"kind" validate:"required"`
@@ -44,18 +57,30 @@ type Config struct {
 	Method       tools.HTTPMethod  `yaml:"method" validate:"required"`
 	Headers      map[string]string `yaml:"headers"`
 	RequestBody  string            `yaml:"requestBody"`
+	PathParams   tools.Parameters  `yaml:"pathParams"`
 	QueryParams  tools.Parameters  `yaml:"queryParams"`
 	BodyParams   tools.Parameters  `yaml:"bodyParams"`
 	HeaderParams tools.Parameters  `yaml:"headerParams"`
+	Examples     []map[string]any  `yaml:"examples"`
 }
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.ReferencingToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return ToolKind
 }
 
+func (cfg Config) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{
+		Source:                cfg.Source,
+		CompatibleSourceKinds: []string{httpsrc.SourceKind},
+		AuthRequired:          cfg.AuthRequired,
+		Parameters:            slices.Concat(cfg.PathParams, cfg.QueryParams, cfg.BodyParams, cfg.HeaderParams),
+	}
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -69,6 +94,16 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be `http`", ToolKind)
 	}
 
+	// Verify every path parameter has a placeholder in the path
+	for _, p := range cfg.PathParams {
+		if !strings.Contains(cfg.Path, "{"+p.GetName()+"}") {
+			return nil, fmt.Errorf("path %q has no placeholder {%s} for path parameter %q", cfg.Path, p.GetName(), p.GetName())
+		}
+		if !p.IsRequired() && p.GetDefault() == nil {
+			return nil, fmt.Errorf("path parameter %q must be required or have a default", p.GetName())
+		}
+	}
+
 	// Create URL based on BaseURL and Path
 	// Attach query parameters
 	u, err := url.Parse(s.BaseURL + cfg.Path)
@@ -90,10 +125,11 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 	maps.Copy(combinedHeaders, cfg.Headers)
 
 	// Create a slice for all parameters
-	allParameters := slices.Concat(cfg.BodyParams, cfg.HeaderParams, cfg.QueryParams)
+	allParameters := slices.Concat(cfg.PathParams, cfg.BodyParams, cfg.HeaderParams, cfg.QueryParams)
 
 	// Create parameter MCP manifest
 	paramManifest := slices.Concat(
+		cfg.PathParams.Manifest(),
 		cfg.QueryParams.Manifest(),
 		cfg.BodyParams.Manifest(),
 		cfg.HeaderParams.Manifest(),
@@ -102,12 +138,14 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		paramManifest = make([]tools.ParameterManifest, 0)
 	}
 
+	pathMcpManifest := cfg.PathParams.McpManifest()
 	queryMcpManifest := cfg.QueryParams.McpManifest()
 	bodyMcpManifest := cfg.BodyParams.McpManifest()
 	headerMcpManifest := cfg.HeaderParams.McpManifest()
 
 	// Concatenate parameters for MCP `required` field
 	concatRequiredManifest := slices.Concat(
+		pathMcpManifest.Required,
 		queryMcpManifest.Required,
 		bodyMcpManifest.Required,
 		headerMcpManifest.Required,
@@ -115,6 +153,9 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 
 	// Concatenate parameters for MCP `properties` field
 	concatPropertiesManifest := make(map[string]tools.ParameterMcpManifest)
+	for name, p := range pathMcpManifest.Properties {
+		concatPropertiesManifest[name] = p
+	}
 	for name, p := range queryMcpManifest.Properties {
 		concatPropertiesManifest[name] = p
 	}
@@ -130,16 +171,20 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Type:       "object",
 		Properties: concatPropertiesManifest,
 		Required:   concatRequiredManifest,
+		Examples:   cfg.Examples,
 	}
 
 	// Verify there are no duplicate parameter names
 	seenNames := make(map[string]bool)
 	for _, param := range paramManifest {
 		if _, exists := seenNames[param.Name]; exists {
-			return nil, fmt.Errorf("parameter name must be unique across queryParams, bodyParams, and headerParams. Duplicate parameter: %s", param.Name)
+			return nil, fmt.Errorf("parameter name must be unique across pathParams, queryParams, bodyParams, and headerParams. Duplicate parameter: %s", param.Name)
 		}
 		seenNames[param.Name] = true
 	}
+	if err := tools.CheckExamples(allParameters, cfg.Examples); err != nil {
+		return nil, err
+	}
 
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
@@ -155,13 +200,14 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		Method:       cfg.Method,
 		AuthRequired: cfg.AuthRequired,
 		RequestBody:  cfg.RequestBody,
+		PathParams:   cfg.PathParams,
 		QueryParams:  cfg.QueryParams,
 		BodyParams:   cfg.BodyParams,
 		HeaderParams: cfg.HeaderParams,
 		Headers:      combinedHeaders,
 		Client:       s.Client,
 		AllParams:    allParameters,
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: paramManifest},
+		manifest:     tools.Manifest{Description: cfg.Description, Parameters: paramManifest, Examples: cfg.Examples},
 		mcpManifest:  mcpManifest,
 	}, nil
 }
@@ -179,6 +225,7 @@ type Tool struct {
 	Method       tools.HTTPMethod  `yaml:"method"`
 	Headers      map[string]string `yaml:"headers"`
 	RequestBody  string            `yaml:"requestBody"`
+	PathParams   tools.Parameters  `yaml:"pathParams"`
 	QueryParams  tools.Parameters  `yaml:"queryParams"`
 	BodyParams   tools.Parameters  `yaml:"bodyParams"`
 	HeaderParams tools.Parameters  `yaml:"headerParams"`
@@ -198,6 +245,26 @@ func convertParamToJSON(param any) (string, error) {
 	return string(jsonData), nil
 }
 
+// jsonNull is the value of an omitted optional parameter without a default, or
+// of a null, which is written as JSON null instead of "<no value>".
+type jsonNull struct{}
+
+func (jsonNull) String() string { return "null" }
+
+func (jsonNull) MarshalJSON() ([]byte, error) { return []byte("null"), nil }
+
+// jsonObject is the value of an object parameter, which is written as JSON
+// both by the "json" function and when inserted into a template as is.
+type jsonObject map[string]any
+
+func (o jsonObject) String() string {
+	s, err := convertParamToJSON(map[string]any(o))
+	if err != nil {
+		return err.Error()
+	}
+	return s
+}
+
 // Helper function to generate the HTTP request body upon Tool invocation.
 func getRequestBody(bodyParams tools.Parameters, requestBodyPayload string, paramsMap map[string]any) (string, error) {
 	// Create a map for request body parameters
@@ -208,6 +275,12 @@ func getRequestBody(bodyParams tools.Parameters, requestBodyPayload string, para
 		if !ok {
 			return "", fmt.Errorf("missing request body parameter %s", k)
 		}
+		switch obj := v.(type) {
+		case nil:
+			v = jsonNull{}
+		case map[string]any:
+			v = jsonObject(obj)
+		}
 		bodyParamsMap[k] = v
 	}
 
@@ -228,14 +301,35 @@ func getRequestBody(bodyParams tools.Parameters, requestBodyPayload string, para
 }
 
 // Helper function to generate the HTTP request URL upon Tool invocation.
-func getURL(u *url.URL, queryParams tools.Parameters, paramsMap map[string]any) (string, error) {
+func getURL(u *url.URL, pathParams, queryParams tools.Parameters, paramsMap map[string]any) (string, error) {
+	// copy the URL so that the tool's URL is not modified
+	result := *u
+
+	// Replace {name} placeholders in the path, escaping each value as a single segment
+	path, rawPath := result.Path, result.EscapedPath()
+	for _, p := range pathParams {
+		v := fmt.Sprintf("%v", paramsMap[p.GetName()])
+		placeholder := "{" + p.GetName() + "}"
+		path = strings.ReplaceAll(path, placeholder, v)
+		rawPath = strings.ReplaceAll(rawPath, url.PathEscape(placeholder), url.PathEscape(v))
+	}
+	result.Path, result.RawPath = path, rawPath
+
 	// Set dynamic query parameters
-	query := u.Query()
+	query := result.Query()
 	for _, p := range queryParams {
-		query.Add(p.GetName(), fmt.Sprintf("%v", paramsMap[p.GetName()]))
+		v := paramsMap[p.GetName()]
+		if v == nil {
+			// omitted optional parameters are left out of the query
+			continue
+		}
+		if obj, ok := v.(map[string]any); ok {
+			v = jsonObject(obj)
+		}
+		query.Add(p.GetName(), fmt.Sprintf("%v", v))
 	}
-	u.RawQuery = query.Encode()
-	return u.String(), nil
+	result.RawQuery = query.Encode()
+	return result.String(), nil
 }
 
 // Helper function to generate the HTTP headers upon Tool invocation.
@@ -245,7 +339,7 @@ func getHeaders(headerParams tools.Parameters, defaultHeaders map[string]string,
 	maps.Copy(allHeaders, defaultHeaders)
 	for _, p := range headerParams {
 		headerValue, ok := paramsMap[p.GetName()]
-		if ok {
+		if ok && headerValue != nil {
 			if strValue, ok := headerValue.(string); ok {
 				allHeaders[p.GetName()] = strValue
 			} else {
@@ -258,6 +352,12 @@ func getHeaders(headerParams tools.Parameters, defaultHeaders map[string]string,
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
 	paramsMap := params.AsMap()
+	// send dates, timestamps and durations in the format they are accepted in
+	for _, p := range slices.Concat(t.PathParams, t.QueryParams, t.BodyParams, t.HeaderParams) {
+		if v, ok := paramsMap[p.GetName()]; ok {
+			paramsMap[p.GetName()] = tools.FormatValue(p, v)
+		}
+	}
 
 	// Calculate request body
 	requestBody, err := getRequestBody(t.BodyParams, t.RequestBody, paramsMap)
@@ -266,9 +366,9 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 	}
 
 	// Calculate URL
-	urlString, err := getURL(t.URL, t.QueryParams, paramsMap)
+	urlString, err := getURL(t.URL, t.PathParams, t.QueryParams, paramsMap)
 	if err != nil {
-		return nil, fmt.Errorf("error populating query parameters: %s", err)
+		return nil, fmt.Errorf("error populating path and query parameters: %s", err)
 	}
 
 	req, _ := http.NewRequest(string(t.Method), urlString, strings.NewReader(requestBody))
@@ -15,15 +15,22 @@
 package http_test
 
 import (
+	"io"
+	nethttp "net/http"
+	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	httpsrc "github.com/googleapis/genai-toolbox/internal/sources/http"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	http "github.com/googleapis/genai-toolbox/internal/tools/http"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlHTTP(t *testing.T) {
@@ -197,3 +204,203 @@ func TestFailParseFromYamlHTTP(t *testing.T) {
 	}
 
 }
+
+func TestInvokeHTTPPathParams(t *testing.T) {
+	var gotURL string
+	ts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		gotURL = r.URL.RequestURI()
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := httpsrc.Config{Name: "my-api", Kind: httpsrc.SourceKind, BaseURL: ts.URL, Timeout: "10s"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	cfg := http.Config{
+		Name:        "get_pet",
+		Kind:        http.ToolKind,
+		Source:      "my-api",
+		Method:      "GET",
+		Path:        "/owners/{owner}/pets/{id}",
+		Description: "Get a pet.",
+		PathParams:  tools.Parameters{tools.NewStringParameter("owner", "The owner."), tools.NewIntParameter("id", "The pet.")},
+		QueryParams: tools.Parameters{tools.NewStringParameter("fields", "Fields to return.")},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-api": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+
+	// invoke twice to check that values do not leak between invocations
+	for _, owner := range []string{"a/b c", "d"} {
+		params, err := tool.ParseParams(map[string]any{"owner": owner, "id": 7, "fields": "name"}, nil)
+		if err != nil {
+			t.Fatalf("unable to parse params: %s", err)
+		}
+		if _, err := tool.Invoke(ctx, params); err != nil {
+			t.Fatalf("unable to invoke tool: %s", err)
+		}
+		want := "/owners/" + url.PathEscape(owner) + "/pets/7?fields=name"
+		if gotURL != want {
+			t.Fatalf("unexpected request URL: got %q, want %q", gotURL, want)
+		}
+	}
+
+	cfg.Path = "/owners/{owner}/pets"
+	_, err = cfg.Initialize(map[string]sources.Source{"my-api": src})
+	if want := `path "/owners/{owner}/pets" has no placeholder {id} for path parameter "id"`; err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
+
+func TestInvokeHTTPOptionalBodyParams(t *testing.T) {
+	var gotBody string
+	ts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := httpsrc.Config{Name: "my-api", Kind: httpsrc.SourceKind, BaseURL: ts.URL, Timeout: "10s"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	cfg := http.Config{
+		Name:        "add_pet",
+		Kind:        http.ToolKind,
+		Source:      "my-api",
+		Method:      "POST",
+		Path:        "/pets",
+		Description: "Add a pet.",
+		RequestBody: `{"name": {{json .name}}, "age": {{.age}}, "tags": {{json .tags}}}`,
+		BodyParams: tools.Parameters{
+			tools.NewStringParameter("name", "The name."),
+			tools.NewIntParameterWithRequired("age", "The age.", false),
+			tools.NewArrayParameterWithRequired("tags", "The tags.", tools.NewStringParameter("tag", "A tag."), false),
+		},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-api": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"name": "Rex"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.Invoke(ctx, params); err != nil {
+		t.Fatalf("unable to invoke tool: %s", err)
+	}
+	if want := `{"name": "Rex", "age": null, "tags": null}`; gotBody != want {
+		t.Fatalf("unexpected request body: got %q, want %q", gotBody, want)
+	}
+}
+
+func TestInvokeHTTPNullableBodyParams(t *testing.T) {
+	var gotBody string
+	ts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody = string(b)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := httpsrc.Config{Name: "my-api", Kind: httpsrc.SourceKind, BaseURL: ts.URL, Timeout: "10s"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	nickname := tools.NewStringParameter("nickname", "The nickname.")
+	nickname.Nullable = true
+	cfg := http.Config{
+		Name:        "rename_pet",
+		Kind:        http.ToolKind,
+		Source:      "my-api",
+		Method:      "PUT",
+		Path:        "/pets",
+		Description: "Rename a pet.",
+		RequestBody: `{"nickname": {{.nickname}}, "copy": {{json .nickname}}}`,
+		BodyParams:  tools.Parameters{nickname},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-api": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"nickname": nil}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.Invoke(ctx, params); err != nil {
+		t.Fatalf("unable to invoke tool: %s", err)
+	}
+	if want := `{"nickname": null, "copy": null}`; gotBody != want {
+		t.Fatalf("unexpected request body: got %q, want %q", gotBody, want)
+	}
+}
+
+func TestInvokeHTTPObjectParams(t *testing.T) {
+	var gotBody, gotQuery string
+	ts := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBody, gotQuery = string(b), r.URL.Query().Get("filter")
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer ts.Close()
+
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := httpsrc.Config{Name: "my-api", Kind: httpsrc.SourceKind, BaseURL: ts.URL, Timeout: "10s"}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	pet := tools.NewObjectParameter("pet", "The pet.", tools.Parameters{
+		tools.NewStringParameter("name", "The name."),
+		tools.NewMapParameter("labels", "Labels.", tools.NewStringParameter("label", "A label.")),
+	})
+	cfg := http.Config{
+		Name:        "add_pet",
+		Kind:        http.ToolKind,
+		Source:      "my-api",
+		Method:      "POST",
+		Path:        "/pets",
+		Description: "Add a pet.",
+		RequestBody: `{"pet": {{.pet}}, "copy": {{json .pet}}}`,
+		BodyParams:  tools.Parameters{pet},
+		QueryParams: tools.Parameters{tools.NewMapParameter("filter", "A filter.", tools.NewIntParameter("value", "A value."))},
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-api": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{
+		"pet":    map[string]any{"name": "Rex", "labels": map[string]any{"color": "brown"}},
+		"filter": map[string]any{"age": 3},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	if _, err := tool.Invoke(ctx, params); err != nil {
+		t.Fatalf("unable to invoke tool: %s", err)
+	}
+	obj := `{"labels":{"color":"brown"},"name":"Rex"}`
+	if want := `{"pet": ` + obj + `, "copy": ` + obj + `}`; gotBody != want {
+		t.Fatalf("unexpected request body: got %q, want %q", gotBody, want)
+	}
+	if want := `{"age":3}`; gotQuery != want {
+		t.Fatalf("unexpected query parameter: got %q, want %q", gotQuery, want)
+	}
+}
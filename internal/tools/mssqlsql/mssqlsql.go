@@ -17,9 +17,12 @@ package mssqlsql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"slices"
 	"strings"
 
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmssql"
 	"github.com/googleapis/genai-toolbox/internal/sources/mssql"
@@ -28,6 +31,18 @@ import (
 
 const ToolKind string = "mssql-sql"
 
+func init() {
+	tools.Register(ToolKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 type compatibleSource interface {
 	MSSQLDB() *sql.DB
 }
@@ -39,22 +54,34 @@ var _ compatibleSource = &mssql.Source{}
 var compatibleSources = [...]string{cloudsqlmssql.SourceKind, mssql.SourceKind}
 
 type Config struct {
-	Name         string           `yaml:"name" validate:"required"`
-	Kind         string           `yaml:"kind" validate:"required"`
-	Source       string           `yaml:"source" validate:"required"`
-	Description  string           `yaml:"description" validate:"required"`
-	Statement    string           `yaml:"statement" validate:"required"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name               string           `yaml:"name" validate:"required"`
+	Kind               string           `yaml:"kind" validate:"required"`
+	Source             string           `yaml:"source" validate:"required"`
+	Description        string           `yaml:"description" validate:"required"`
+	Statement          string           `yaml:"statement" validate:"required"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	Examples           []map[string]any `yaml:"examples"`
 }
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.ReferencingToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return ToolKind
 }
 
+func (cfg Config) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{
+		Source:                cfg.Source,
+		CompatibleSourceKinds: compatibleSources[:],
+		AuthRequired:          cfg.AuthRequired,
+		Parameters:            slices.Concat(cfg.Parameters, cfg.TemplateParameters),
+	}
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -68,22 +95,34 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", ToolKind, compatibleSources)
 	}
 
+	if err := tools.CheckTemplateParameters(cfg.Statement, cfg.Parameters, cfg.TemplateParameters); err != nil {
+		return nil, err
+	}
+	allParameters := slices.Concat(cfg.Parameters, cfg.TemplateParameters)
+
+	if err := tools.CheckExamples(allParameters, cfg.Examples); err != nil {
+		return nil, err
+	}
+	inputSchema := allParameters.McpManifest()
+	inputSchema.Examples = cfg.Examples
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
-		InputSchema: cfg.Parameters.McpManifest(),
+		InputSchema: inputSchema,
 	}
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         ToolKind,
-		Parameters:   cfg.Parameters,
-		Statement:    cfg.Statement,
-		AuthRequired: cfg.AuthRequired,
-		Db:           s.MSSQLDB(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest()},
-		mcpManifest:  mcpManifest,
+		Name:               cfg.Name,
+		Kind:               ToolKind,
+		Parameters:         cfg.Parameters,
+		TemplateParameters: cfg.TemplateParameters,
+		Statement:          cfg.Statement,
+		AuthRequired:       cfg.AuthRequired,
+		Db:                 s.MSSQLDB(),
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), Examples: cfg.Examples},
+		mcpManifest:        mcpManifest,
 	}
 	return t, nil
 }
@@ -92,10 +131,11 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 var _ tools.Tool = Tool{}
 
 type Tool struct {
-	Name         string           `yaml:"name"`
-	Kind         string           `yaml:"kind"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name               string           `yaml:"name"`
+	Kind               string           `yaml:"kind"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 
 	Db          *sql.DB
 	Statement   string
@@ -104,18 +144,29 @@ type Tool struct {
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
+	statement, params, err := tools.ResolveTemplateParams(t.Statement, t.TemplateParameters, params, tools.Brackets)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve statement: %w", err)
+	}
 	namedArgs := make([]any, 0, len(params))
-	paramsMap := params.AsReversedMap()
 	// To support both named args (e.g @id) and positional args (e.g @p1), check if arg name is contained in the statement.
-	for _, v := range params.AsSlice() {
-		paramName := paramsMap[v]
-		if strings.Contains(t.Statement, "@"+paramName) {
+	for _, p := range params {
+		paramName, v := p.Name, p.Value
+		// objects are bound as JSON text, which SQL Server reads with OPENJSON
+		if obj, ok := v.(map[string]any); ok {
+			b, err := json.Marshal(obj)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode parameter %q: %w", paramName, err)
+			}
+			v = string(b)
+		}
+		if strings.Contains(statement, "@"+paramName) {
 			namedArgs = append(namedArgs, sql.Named(paramName, v))
 		} else {
 			namedArgs = append(namedArgs, v)
 		}
 	}
-	rows, err := t.Db.QueryContext(ctx, t.Statement, namedArgs...)
+	rows, err := t.Db.QueryContext(ctx, statement, namedArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -158,7 +209,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
-	return tools.ParseParams(t.Parameters, data, claims)
+	return tools.ParseParams(slices.Concat(t.Parameters, t.TemplateParameters), data, claims)
 }
 
 func (t Tool) Manifest() tools.Manifest {
@@ -17,8 +17,12 @@ package mysqlsql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"time"
 
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlmysql"
 	"github.com/googleapis/genai-toolbox/internal/sources/mysql"
@@ -27,6 +31,18 @@ import (
 
 const ToolKind string = "mysql-sql"
 
+func init() {
+	tools.Register(ToolKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 type compatibleSource interface {
 	MySQLPool() *sql.DB
 }
@@ -38,22 +54,34 @@ var _ compatibleSource = &mysql.Source{}
 var compatibleSources = [...]string{cloudsqlmysql.SourceKind, mysql.SourceKind}
 
 type Config struct {
-	Name         string           `yaml:"name" validate:"required"`
-	Kind         string           `yaml:"kind" validate:"required"`
-	Source       string           `yaml:"source" validate:"required"`
-	Description  string           `yaml:"description" validate:"required"`
-	Statement    string           `yaml:"statement" validate:"required"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name               string           `yaml:"name" validate:"required"`
+	Kind               string           `yaml:"kind" validate:"required"`
+	Source             string           `yaml:"source" validate:"required"`
+	Description        string           `yaml:"description" validate:"required"`
+	Statement          string           `yaml:"statement" validate:"required"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	Examples           []map[string]any `yaml:"examples"`
 }
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.ReferencingToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return ToolKind
 }
 
+func (cfg Config) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{
+		Source:                cfg.Source,
+		CompatibleSourceKinds: compatibleSources[:],
+		AuthRequired:          cfg.AuthRequired,
+		Parameters:            slices.Concat(cfg.Parameters, cfg.TemplateParameters),
+	}
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -67,22 +95,34 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", ToolKind, compatibleSources)
 	}
 
+	if err := tools.CheckTemplateParameters(cfg.Statement, cfg.Parameters, cfg.TemplateParameters); err != nil {
+		return nil, err
+	}
+	allParameters := slices.Concat(cfg.Parameters, cfg.TemplateParameters)
+
+	if err := tools.CheckExamples(allParameters, cfg.Examples); err != nil {
+		return nil, err
+	}
+	inputSchema := allParameters.McpManifest()
+	inputSchema.Examples = cfg.Examples
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
-		InputSchema: cfg.Parameters.McpManifest(),
+		InputSchema: inputSchema,
 	}
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         ToolKind,
-		Parameters:   cfg.Parameters,
-		Statement:    cfg.Statement,
-		AuthRequired: cfg.AuthRequired,
-		Pool:         s.MySQLPool(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest()},
-		mcpManifest:  mcpManifest,
+		Name:               cfg.Name,
+		Kind:               ToolKind,
+		Parameters:         cfg.Parameters,
+		TemplateParameters: cfg.TemplateParameters,
+		Statement:          cfg.Statement,
+		AuthRequired:       cfg.AuthRequired,
+		Pool:               s.MySQLPool(),
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), Examples: cfg.Examples},
+		mcpManifest:        mcpManifest,
 	}
 	return t, nil
 }
@@ -91,10 +131,11 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 var _ tools.Tool = Tool{}
 
 type Tool struct {
-	Name         string           `yaml:"name"`
-	Kind         string           `yaml:"kind"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name               string           `yaml:"name"`
+	Kind               string           `yaml:"kind"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 
 	Pool        *sql.DB
 	Statement   string
@@ -102,10 +143,50 @@ type Tool struct {
 	mcpManifest tools.McpManifest
 }
 
+// bindValues returns the values to bind to the statement. Durations are bound
+// as TIME values, since the driver would send them as nanoseconds, and objects
+// as JSON text, which MySQL converts to JSON.
+func bindValues(params tools.ParamValues) ([]any, error) {
+	values := params.AsSlice()
+	for i, v := range values {
+		switch v := v.(type) {
+		case time.Duration:
+			values[i] = timeValue(v)
+		case map[string]any:
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode parameter %q: %w", params[i].Name, err)
+			}
+			values[i] = string(b)
+		}
+	}
+	return values, nil
+}
+
+// timeValue formats "d" as a MySQL TIME value, such as "-01:30:00.000000".
+func timeValue(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	h := d / time.Hour
+	m := d % time.Hour / time.Minute
+	s := d % time.Minute / time.Second
+	us := d % time.Second / time.Microsecond
+	return fmt.Sprintf("%s%02d:%02d:%02d.%06d", sign, h, m, s, us)
+}
+
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
-	sliceParams := params.AsSlice()
+	statement, params, err := tools.ResolveTemplateParams(t.Statement, t.TemplateParameters, params, tools.Backticks)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve statement: %w", err)
+	}
+	sliceParams, err := bindValues(params)
+	if err != nil {
+		return nil, err
+	}
 
-	results, err := t.Pool.QueryContext(ctx, t.Statement, sliceParams...)
+	results, err := t.Pool.QueryContext(ctx, statement, sliceParams...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -153,7 +234,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
-	return tools.ParseParams(t.Parameters, data, claims)
+	return tools.ParseParams(slices.Concat(t.Parameters, t.TemplateParameters), data, claims)
 }
 
 func (t Tool) Manifest() tools.Manifest {
@@ -15,10 +15,14 @@
 package tools
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"regexp"
+	"slices"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/googleapis/genai-toolbox/internal/util"
 )
@@ -29,6 +33,7 @@ const (
 	typeFloat  = "float"
 	typeBool   = "boolean"
 	typeArray  = "array"
+	typeObject = "object"
 )
 
 // ParamValues is an ordered list of ParamValue
@@ -38,6 +43,19 @@ type ParamValues []ParamValue
 type ParamValue struct {
 	Name  string
 	Value any
+	// Sensitive values are redacted when formatted, such as in logs.
+	Sensitive bool
+}
+
+// Redacted replaces sensitive values when they are formatted.
+const Redacted = "[REDACTED]"
+
+// String formats the parameter as "{name value}", redacting sensitive values.
+func (p ParamValue) String() string {
+	if p.Sensitive {
+		return fmt.Sprintf("{%s %s}", p.Name, Redacted)
+	}
+	return fmt.Sprintf("{%s %v}", p.Name, p.Value)
 }
 
 // AsSlice returns a slice of the Param's values (in order).
@@ -97,7 +115,14 @@ func (p ParamValues) AsMapWithDollarPrefix() map[string]interface{} {
 	return params
 }
 
-func parseFromAuthService(paramAuthServices []ParamAuthService, claimsMap map[string]map[string]any) (any, error) {
+// ParseFromAuthService returns the claim specified by the first of the given
+// auth services that the request was verified against.
+func ParseFromAuthService(paramAuthServices []ParamAuthService, claimsMap map[string]map[string]any) (any, error) {
+	v, _, err := parseFromAuthService(paramAuthServices, claimsMap)
+	return v, err
+}
+
+func parseFromAuthService(paramAuthServices []ParamAuthService, claimsMap map[string]map[string]any) (any, ParamAuthService, error) {
 	// parse a parameter from claims using its specified auth services
 	for _, a := range paramAuthServices {
 		claims, ok := claimsMap[a.Name]
@@ -105,45 +130,132 @@ func parseFromAuthService(paramAuthServices []ParamAuthService, claimsMap map[st
 			// not validated for this authservice, skip to the next one
 			continue
 		}
-		v, ok := claims[a.Field]
-		if !ok {
-			// claims do not contain specified field
-			return nil, fmt.Errorf("no field named %s in claims", a.Field)
-		}
-		return v, nil
+		v, err := a.claim(claims)
+		return v, a, err
+	}
+	return nil, ParamAuthService{}, fmt.Errorf("missing or invalid authentication header")
+}
+
+// ParamError is the reason the value of a parameter was rejected.
+type ParamError struct {
+	Param  string `json:"param"`
+	Reason string `json:"reason"`
+	err    error
+}
+
+func (e *ParamError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ParamError) Unwrap() error {
+	return e.err
+}
+
+// ParamErrors are the errors of every rejected parameter of a request, so that
+// they can all be fixed at once.
+type ParamErrors []*ParamError
+
+func (e ParamErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ParamErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
 	}
-	return nil, fmt.Errorf("missing or invalid authentication header")
+	return errs
 }
 
 // ParseParams is a helper function for parsing Parameters from an arbitraryJSON object.
+// All invalid parameters are reported in a ParamErrors.
 func ParseParams(ps Parameters, data map[string]any, claimsMap map[string]map[string]any) (ParamValues, error) {
 	params := make([]ParamValue, 0, len(ps))
+	var errs ParamErrors
 	for _, p := range ps {
-		var v any
-		paramAuthServices := p.GetAuthServices()
-		name := p.GetName()
-		if len(paramAuthServices) == 0 {
-			// parse non auth-required parameter
-			var ok bool
-			v, ok = data[name]
-			if !ok {
-				return nil, fmt.Errorf("parameter %q is required", name)
-			}
-		} else {
-			// parse authenticated parameter
-			var err error
-			v, err = parseFromAuthService(paramAuthServices, claimsMap)
-			if err != nil {
-				return nil, fmt.Errorf("error parsing authenticated parameter %q: %w", name, err)
-			}
+		v, err := parseParam(p, data, claimsMap)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		params = append(params, ParamValue{Name: p.GetName(), Value: v, Sensitive: p.IsSensitive()})
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return params, nil
+}
+
+// parseNull returns the value of a parameter given as null, which is bound as
+// NULL if the parameter is nullable.
+func parseNull(p Parameter) (any, *ParamError) {
+	if !p.IsNullable() {
+		return nil, &ParamError{p.GetName(), "parameter is not nullable", fmt.Errorf("parameter %q is not nullable", p.GetName())}
+	}
+	return nil, nil
+}
+
+func parseParam(p Parameter, data map[string]any, claimsMap map[string]map[string]any) (any, *ParamError) {
+	name := p.GetName()
+	paramAuthServices := p.GetAuthServices()
+	if len(paramAuthServices) > 0 {
+		// parse authenticated parameter
+		v, a, err := parseFromAuthService(paramAuthServices, claimsMap)
+		if err != nil {
+			return nil, &ParamError{name, err.Error(), fmt.Errorf("error parsing authenticated parameter %q: %w", name, err)}
+		}
+		if v == nil {
+			return parseNull(p)
+		}
+		// identity providers may send a single value for a list claim
+		if _, isList := v.([]any); p.GetType() == typeArray && !isList {
+			v = []any{v}
 		}
 		newV, err := p.Parse(v)
 		if err != nil {
-			return nil, fmt.Errorf("unable to parse value for %q: %w", name, err)
+			return nil, parseError(p, claimParseError(p, a, sensitiveError(p, err)))
 		}
-		params = append(params, ParamValue{Name: name, Value: newV})
+		return newV, nil
 	}
-	return params, nil
+
+	// parse non auth-required parameter
+	v, ok := data[name]
+	if !ok {
+		if p.IsRequired() {
+			return nil, &ParamError{name, "parameter is required", fmt.Errorf("parameter %q is required", name)}
+		}
+		// defaults are parsed when the parameter is configured, and a
+		// missing default is bound as NULL
+		return p.GetDefault(), nil
+	}
+	if v == nil {
+		return parseNull(p)
+	}
+	newV, err := p.Parse(v)
+	if err != nil {
+		return nil, parseError(p, sensitiveError(p, err))
+	}
+	return newV, nil
+}
+
+// parseError is the error of a value the parameter "p" rejected.
+func parseError(p Parameter, err error) *ParamError {
+	name := p.GetName()
+	return &ParamError{name, err.Error(), fmt.Errorf("unable to parse value for %q: %w", name, err)}
+}
+
+// sensitiveError replaces the error of Parse for a sensitive parameter, which
+// can include the value, with one that only names the type of the parameter.
+// Errors of other parameters are returned as is.
+func sensitiveError(p Parameter, err error) error {
+	if !p.IsSensitive() {
+		return err
+	}
+	return fmt.Errorf("invalid value for sensitive parameter of type %q", p.GetType())
 }
 
 type Parameter interface {
@@ -152,6 +264,17 @@ type Parameter interface {
 	GetName() string
 	GetType() string
 	GetAuthServices() []ParamAuthService
+	// IsRequired reports whether a value must be given for the parameter.
+	IsRequired() bool
+	// GetDefault returns the value of the parameter when none is given, or
+	// nil to bind it as NULL.
+	GetDefault() any
+	// IsNullable reports whether null is accepted as a value, and bound as
+	// NULL.
+	IsNullable() bool
+	// IsSensitive reports whether values must be redacted from logs and
+	// errors.
+	IsSensitive() bool
 	Parse(any) (any, error)
 	Manifest() ParameterManifest
 	McpManifest() ParameterMcpManifest
@@ -162,6 +285,8 @@ type McpToolsSchema struct {
 	Type       string                          `json:"type"`
 	Properties map[string]ParameterMcpManifest `json:"properties"`
 	Required   []string                        `json:"required"`
+	// Examples are example invocations of the tool.
+	Examples []map[string]any `json:"examples,omitempty"`
 }
 
 // Parameters is a type used to allow unmarshal a list of parameters
@@ -186,6 +311,141 @@ func (c *Parameters) UnmarshalYAML(ctx context.Context, unmarshal func(interface
 // parseParamFromDelayedUnmarshaler is a helper function that is required to parse
 // parameters because there are multiple different types
 func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmarshaler) (Parameter, error) {
+	p, err := decodeParam(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range p.GetAuthServices() {
+		if err := a.check(); err != nil {
+			return nil, fmt.Errorf("invalid auth service %q for parameter %q: %w", a.Name, p.GetName(), err)
+		}
+	}
+	if c, ok := p.(interface{ checkConstraints() error }); ok {
+		if err := c.checkConstraints(); err != nil {
+			return nil, fmt.Errorf("invalid constraints for parameter %q: %w", p.GetName(), err)
+		}
+	}
+	if err := parseDefault(p); err != nil {
+		return nil, err
+	}
+	if err := parseExamples(p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parseDefault checks the default of an optional parameter, replacing it with
+// the value ParseParams would return for it.
+func parseDefault(p Parameter) error {
+	c, ok := p.(interface{ common() *CommonParameter })
+	if !ok || c.common().Default == nil {
+		return nil
+	}
+	cp := c.common()
+	if cp.Required != nil && *cp.Required {
+		return fmt.Errorf("parameter %q is required and cannot have a default", cp.Name)
+	}
+	if len(cp.AuthServices) > 0 || len(cp.AuthSources) > 0 {
+		return fmt.Errorf("parameter %q is read from auth services and cannot have a default", cp.Name)
+	}
+	if cp.Sensitive {
+		// defaults are listed in manifests
+		return fmt.Errorf("parameter %q is sensitive and cannot have a default", cp.Name)
+	}
+	_, parsed, err := parseConfigValue(p, cp.Default)
+	if err != nil {
+		return fmt.Errorf("invalid default for parameter %q: %w", cp.Name, err)
+	}
+	cp.Default = parsed
+	return nil
+}
+
+// parseExamples checks the examples of a parameter, replacing them with the
+// values they are sent as in a request body.
+func parseExamples(p Parameter) error {
+	c, ok := p.(interface{ common() *CommonParameter })
+	if !ok || len(c.common().Examples) == 0 {
+		return nil
+	}
+	cp := c.common()
+	if len(cp.AuthServices) > 0 || len(cp.AuthSources) > 0 {
+		return fmt.Errorf("parameter %q is read from auth services and cannot have examples", cp.Name)
+	}
+	if cp.Sensitive {
+		// examples are listed in manifests
+		return fmt.Errorf("parameter %q is sensitive and cannot have examples", cp.Name)
+	}
+	for i, example := range cp.Examples {
+		v, _, err := parseConfigValue(p, example)
+		if err != nil {
+			return fmt.Errorf("invalid example #%d for parameter %q: %w", i, cp.Name, err)
+		}
+		cp.Examples[i] = v
+	}
+	return nil
+}
+
+// parseConfigValue decodes a configured value like a request body, so that it
+// is parsed the same, and returns both the decoded and the parsed value.
+func parseConfigValue(p Parameter, value any) (any, any, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, nil, err
+	}
+	if v == nil && p.IsNullable() {
+		return nil, nil, nil
+	}
+	parsed, err := p.Parse(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	return v, parsed, nil
+}
+
+// CheckExamples checks example invocations of a tool, which map the names of
+// its parameters to values. Examples must give every required parameter, and
+// can't give parameters read from auth services or sensitive parameters.
+func CheckExamples(ps Parameters, examples []map[string]any) error {
+	for i, example := range examples {
+		for name := range example {
+			if !slices.ContainsFunc(ps, func(p Parameter) bool { return p.GetName() == name }) {
+				return fmt.Errorf("invalid example #%d: unknown parameter %q", i, name)
+			}
+		}
+		for _, p := range ps {
+			name := p.GetName()
+			v, ok := example[name]
+			switch {
+			case len(p.GetAuthServices()) > 0:
+				if ok {
+					return fmt.Errorf("invalid example #%d: parameter %q is read from auth services", i, name)
+				}
+			case p.IsSensitive():
+				if ok {
+					return fmt.Errorf("invalid example #%d: parameter %q is sensitive", i, name)
+				}
+			case !ok:
+				if p.IsRequired() {
+					return fmt.Errorf("invalid example #%d: parameter %q is required", i, name)
+				}
+			default:
+				if _, _, err := parseConfigValue(p, v); err != nil {
+					return fmt.Errorf("invalid example #%d: unable to parse value for %q: %w", i, name, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// decodeParam decodes a parameter of any type.
+func decodeParam(ctx context.Context, u *util.DelayedUnmarshaler) (Parameter, error) {
 	var p map[string]any
 	err := u.Unmarshal(&p)
 	if err != nil {
@@ -205,64 +465,46 @@ func parseParamFromDelayedUnmarshaler(ctx context.Context, u *util.DelayedUnmars
 	if err != nil {
 		return nil, err
 	}
-	switch t {
-	case typeString:
-		a := &StringParameter{}
-		if err := dec.DecodeContext(ctx, a); err != nil {
-			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
-		}
-		if a.AuthSources != nil {
-			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
-			a.AuthServices = append(a.AuthServices, a.AuthSources...)
-			a.AuthSources = nil
-		}
-		return a, nil
-	case typeInt:
-		a := &IntParameter{}
-		if err := dec.DecodeContext(ctx, a); err != nil {
-			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
-		}
-		if a.AuthSources != nil {
-			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
-			a.AuthServices = append(a.AuthServices, a.AuthSources...)
-			a.AuthSources = nil
-		}
-		return a, nil
-	case typeFloat:
-		a := &FloatParameter{}
-		if err := dec.DecodeContext(ctx, a); err != nil {
-			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
-		}
-		if a.AuthSources != nil {
-			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
-			a.AuthServices = append(a.AuthServices, a.AuthSources...)
-			a.AuthSources = nil
-		}
-		return a, nil
-	case typeBool:
-		a := &BooleanParameter{}
-		if err := dec.DecodeContext(ctx, a); err != nil {
-			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
-		}
-		if a.AuthSources != nil {
-			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
-			a.AuthServices = append(a.AuthServices, a.AuthSources...)
-			a.AuthSources = nil
-		}
-		return a, nil
-	case typeArray:
-		a := &ArrayParameter{}
-		if err := dec.DecodeContext(ctx, a); err != nil {
-			return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
-		}
-		if a.AuthSources != nil {
-			logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
-			a.AuthServices = append(a.AuthServices, a.AuthSources...)
-			a.AuthSources = nil
-		}
-		return a, nil
-	}
-	return nil, fmt.Errorf("%q is not valid type for a parameter!", t)
+	typ, _ := t.(string)
+	newParam, ok := parameterTypes[typ]
+	if !ok {
+		return nil, fmt.Errorf("%q is not valid type for a parameter!", t)
+	}
+	a := newParam()
+	if err := dec.DecodeContext(ctx, a); err != nil {
+		return nil, fmt.Errorf("unable to parse as %q: %w", t, err)
+	}
+	cp := a.(interface{ common() *CommonParameter }).common()
+	if cp.AuthSources != nil {
+		logger.WarnContext(ctx, "`authSources` is deprecated, use `authServices` for parameters instead")
+		cp.AuthServices = append(cp.AuthServices, cp.AuthSources...)
+		cp.AuthSources = nil
+	}
+	return a, nil
+}
+
+// parameterTypes maps each supported type to a constructor of an empty
+// Parameter of that type, which parameters are decoded into.
+var parameterTypes = map[string]func() Parameter{
+	typeString:    func() Parameter { return &StringParameter{} },
+	typeInt:       func() Parameter { return &IntParameter{} },
+	typeFloat:     func() Parameter { return &FloatParameter{} },
+	typeBool:      func() Parameter { return &BooleanParameter{} },
+	typeArray:     func() Parameter { return &ArrayParameter{} },
+	typeObject:    func() Parameter { return &ObjectParameter{} },
+	typeDate:      func() Parameter { return &DateParameter{} },
+	typeTimestamp: func() Parameter { return &TimestampParameter{} },
+	typeDuration:  func() Parameter { return &DurationParameter{} },
+}
+
+// ParameterTypes returns an empty Parameter for each supported type, so that
+// their fields can be inspected.
+func ParameterTypes() map[string]Parameter {
+	out := make(map[string]Parameter, len(parameterTypes))
+	for typ, newParam := range parameterTypes {
+		out[typ] = newParam()
+	}
+	return out
 }
 
 func (ps Parameters) Manifest() []ParameterManifest {
@@ -280,8 +522,9 @@ func (ps Parameters) McpManifest() McpToolsSchema {
 	for _, p := range ps {
 		name := p.GetName()
 		properties[name] = p.McpManifest()
-		// all parameters are added to the required field
-		required = append(required, name)
+		if p.IsRequired() {
+			required = append(required, name)
+		}
 	}
 
 	return McpToolsSchema{
@@ -296,15 +539,56 @@ type ParameterManifest struct {
 	Name         string             `json:"name"`
 	Type         string             `json:"type"`
 	Description  string             `json:"description"`
+	Required     bool               `json:"required"`
+	Nullable     bool               `json:"nullable,omitempty"`
+	Default      any                `json:"default,omitempty"`
+	Examples     []any              `json:"examples,omitempty"`
 	AuthServices []string           `json:"authSources"`
 	Items        *ParameterManifest `json:"items,omitempty"`
+	// Properties and AdditionalProperties describe the fields of an object.
+	Properties           []ParameterManifest `json:"properties,omitempty"`
+	AdditionalProperties *ParameterManifest  `json:"additionalProperties,omitempty"`
 }
 
 // ParameterMcpManifest represents properties when served as part of a ToolMcpManifest.
 type ParameterMcpManifest struct {
 	Type        string                `json:"type"`
 	Description string                `json:"description"`
+	Format      string                `json:"format,omitempty"`
+	Default     any                   `json:"default,omitempty"`
+	Examples    []any                 `json:"examples,omitempty"`
+	Enum        []any                 `json:"enum,omitempty"`
+	Minimum     any                   `json:"minimum,omitempty"`
+	Maximum     any                   `json:"maximum,omitempty"`
+	Pattern     string                `json:"pattern,omitempty"`
+	MinLength   *int                  `json:"minLength,omitempty"`
+	MaxLength   *int                  `json:"maxLength,omitempty"`
+	MinItems    *int                  `json:"minItems,omitempty"`
+	MaxItems    *int                  `json:"maxItems,omitempty"`
 	Items       *ParameterMcpManifest `json:"items,omitempty"`
+	// Properties, Required and AdditionalProperties describe the fields of
+	// an object. AdditionalProperties is either false or the schema of the
+	// values of undeclared fields.
+	Properties           map[string]ParameterMcpManifest `json:"properties,omitempty"`
+	Required             []string                        `json:"required,omitempty"`
+	AdditionalProperties any                             `json:"additionalProperties,omitempty"`
+	// Nullable adds "null" to the type, and to the enum if there is one.
+	Nullable bool `json:"-"`
+}
+
+// MarshalJSON lists the type of nullable parameters as [<type>, "null"].
+func (m ParameterMcpManifest) MarshalJSON() ([]byte, error) {
+	type manifest ParameterMcpManifest
+	if !m.Nullable {
+		return json.Marshal(manifest(m))
+	}
+	if len(m.Enum) > 0 {
+		m.Enum = append(slices.Clip(m.Enum), nil)
+	}
+	return json.Marshal(struct {
+		manifest
+		Type []string `json:"type"`
+	}{manifest(m), []string{m.Type, "null"}})
 }
 
 // CommonParameter are default fields that are emebdding in most Parameter implementations. Embedding this stuct will give the object Name() and Type() functions.
@@ -314,6 +598,20 @@ type CommonParameter struct {
 	Desc         string             `yaml:"description" validate:"required"`
 	AuthServices []ParamAuthService `yaml:"authServices"`
 	AuthSources  []ParamAuthService `yaml:"authSources"` // Deprecated: Kept for compatibility.
+	// Required defaults to true, unless a Default is given.
+	Required *bool `yaml:"required"`
+	Default  any   `yaml:"default"`
+	// Nullable parameters accept null, which is bound as NULL.
+	Nullable bool `yaml:"nullable"`
+	// Sensitive parameters have their values redacted from logs and errors.
+	Sensitive bool `yaml:"sensitive"`
+	// Examples are values of the parameter, listed in manifests to show
+	// agents the expected format.
+	Examples []any `yaml:"examples"`
+}
+
+func (p *CommonParameter) common() *CommonParameter {
+	return p
 }
 
 // GetName returns the name specified for the Parameter.
@@ -326,6 +624,29 @@ func (p *CommonParameter) GetType() string {
 	return p.Type
 }
 
+// IsRequired reports whether a value must be given for the Parameter.
+func (p *CommonParameter) IsRequired() bool {
+	if p.Required != nil {
+		return *p.Required
+	}
+	return p.Default == nil
+}
+
+// GetDefault returns the default value of the Parameter.
+func (p *CommonParameter) GetDefault() any {
+	return p.Default
+}
+
+// IsNullable reports whether the Parameter accepts null.
+func (p *CommonParameter) IsNullable() bool {
+	return p.Nullable
+}
+
+// IsSensitive reports whether the values of the Parameter are redacted.
+func (p *CommonParameter) IsSensitive() bool {
+	return p.Sensitive
+}
+
 // Manifest returns the manifest for the Parameter.
 func (p *CommonParameter) Manifest() ParameterManifest {
 	// only list ParamAuthService names (without fields) in manifest
@@ -337,6 +658,10 @@ func (p *CommonParameter) Manifest() ParameterManifest {
 		Name:         p.Name,
 		Type:         p.Type,
 		Description:  p.Desc,
+		Required:     p.IsRequired(),
+		Nullable:     p.Nullable,
+		Default:      p.Default,
+		Examples:     p.Examples,
 		AuthServices: authNames,
 	}
 }
@@ -346,6 +671,9 @@ func (p *CommonParameter) McpManifest() ParameterMcpManifest {
 	return ParameterMcpManifest{
 		Type:        p.Type,
 		Description: p.Desc,
+		Default:     p.Default,
+		Examples:    p.Examples,
+		Nullable:    p.Nullable,
 	}
 }
 
@@ -361,8 +689,13 @@ func (e ParseTypeError) Error() string {
 }
 
 type ParamAuthService struct {
-	Name  string `yaml:"name"`
+	Name string `yaml:"name"`
+	// Field is the name of a claim, or a path to a nested claim such as
+	// "address.country" or "groups[0]".
 	Field string `yaml:"field"`
+	// Transforms are applied in order to string claims, or to each string of
+	// an array claim.
+	Transforms []string `yaml:"transforms"`
 }
 
 // NewStringParameter is a convenience function for initializing a StringParameter.
@@ -389,11 +722,40 @@ func NewStringParameterWithAuth(name, desc string, authServices []ParamAuthServi
 	}
 }
 
+// NewStringParameterWithDefault is a convenience function for initializing an optional StringParameter with a default value.
+func NewStringParameterWithDefault(name, desc string, defaultV any) *StringParameter {
+	return &StringParameter{
+		CommonParameter: CommonParameter{
+			Name:    name,
+			Type:    typeString,
+			Desc:    desc,
+			Default: defaultV,
+		},
+	}
+}
+
+// NewStringParameterWithRequired is a convenience function for initializing a StringParameter that may be optional.
+func NewStringParameterWithRequired(name, desc string, required bool) *StringParameter {
+	return &StringParameter{
+		CommonParameter: CommonParameter{
+			Name:     name,
+			Type:     typeString,
+			Desc:     desc,
+			Required: &required,
+		},
+	}
+}
+
 var _ Parameter = &StringParameter{}
 
 // StringParameter is a parameter representing the "string" type.
 type StringParameter struct {
 	CommonParameter `yaml:",inline"`
+	AllowedValues   []string `yaml:"allowedValues"`
+	// Pattern is a regular expression that values must match.
+	Pattern   string `yaml:"pattern"`
+	MinLength *int   `yaml:"minLength"`
+	MaxLength *int   `yaml:"maxLength"`
 }
 
 // Parse casts the value "v" as a "string".
@@ -402,8 +764,41 @@ func (p *StringParameter) Parse(v any) (any, error) {
 	if !ok {
 		return nil, &ParseTypeError{p.Name, p.Type, v}
 	}
+	if len(p.AllowedValues) > 0 && !slices.Contains(p.AllowedValues, newV) {
+		return nil, fmt.Errorf("%q is not one of the allowed values %q", newV, p.AllowedValues)
+	}
+	length := utf8.RuneCountInString(newV)
+	if p.MinLength != nil && length < *p.MinLength {
+		return nil, fmt.Errorf("%q is shorter than the minimum length of %d", newV, *p.MinLength)
+	}
+	if p.MaxLength != nil && length > *p.MaxLength {
+		return nil, fmt.Errorf("%q is longer than the maximum length of %d", newV, *p.MaxLength)
+	}
+	if p.Pattern != "" {
+		// the pattern is checked when the parameter is configured
+		if matched, _ := regexp.MatchString(p.Pattern, newV); !matched {
+			return nil, fmt.Errorf("%q does not match the pattern %q", newV, p.Pattern)
+		}
+	}
 	return newV, nil
 }
+
+func (p *StringParameter) checkConstraints() error {
+	if _, err := regexp.Compile(p.Pattern); err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+	return checkRange(p.MinLength, p.MaxLength, "minLength", "maxLength")
+}
+
+// McpManifest returns the MCP manifest for the StringParameter.
+func (p *StringParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Enum = anySlice(p.AllowedValues)
+	m.Pattern = p.Pattern
+	m.MinLength, m.MaxLength = p.MinLength, p.MaxLength
+	return m
+}
+
 func (p *StringParameter) GetAuthServices() []ParamAuthService {
 	return p.AuthServices
 }
@@ -432,11 +827,38 @@ func NewIntParameterWithAuth(name, desc string, authServices []ParamAuthService)
 	}
 }
 
+// NewIntParameterWithDefault is a convenience function for initializing an optional IntParameter with a default value.
+func NewIntParameterWithDefault(name, desc string, defaultV any) *IntParameter {
+	return &IntParameter{
+		CommonParameter: CommonParameter{
+			Name:    name,
+			Type:    typeInt,
+			Desc:    desc,
+			Default: defaultV,
+		},
+	}
+}
+
+// NewIntParameterWithRequired is a convenience function for initializing a IntParameter that may be optional.
+func NewIntParameterWithRequired(name, desc string, required bool) *IntParameter {
+	return &IntParameter{
+		CommonParameter: CommonParameter{
+			Name:     name,
+			Type:     typeInt,
+			Desc:     desc,
+			Required: &required,
+		},
+	}
+}
+
 var _ Parameter = &IntParameter{}
 
 // IntParameter is a parameter representing the "int" type.
 type IntParameter struct {
 	CommonParameter `yaml:",inline"`
+	AllowedValues   []int `yaml:"allowedValues"`
+	MinValue        *int  `yaml:"minValue"`
+	MaxValue        *int  `yaml:"maxValue"`
 }
 
 func (p *IntParameter) Parse(v any) (any, error) {
@@ -457,9 +879,29 @@ func (p *IntParameter) Parse(v any) (any, error) {
 		}
 		out = int(newI)
 	}
+	if err := checkValue(out, p.AllowedValues, p.MinValue, p.MaxValue); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
+func (p *IntParameter) checkConstraints() error {
+	return checkRange(p.MinValue, p.MaxValue, "minValue", "maxValue")
+}
+
+// McpManifest returns the MCP manifest for the IntParameter.
+func (p *IntParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Enum = anySlice(p.AllowedValues)
+	if p.MinValue != nil {
+		m.Minimum = *p.MinValue
+	}
+	if p.MaxValue != nil {
+		m.Maximum = *p.MaxValue
+	}
+	return m
+}
+
 func (p *IntParameter) GetAuthServices() []ParamAuthService {
 	return p.AuthServices
 }
@@ -488,11 +930,38 @@ func NewFloatParameterWithAuth(name, desc string, authServices []ParamAuthServic
 	}
 }
 
+// NewFloatParameterWithDefault is a convenience function for initializing an optional FloatParameter with a default value.
+func NewFloatParameterWithDefault(name, desc string, defaultV any) *FloatParameter {
+	return &FloatParameter{
+		CommonParameter: CommonParameter{
+			Name:    name,
+			Type:    typeFloat,
+			Desc:    desc,
+			Default: defaultV,
+		},
+	}
+}
+
+// NewFloatParameterWithRequired is a convenience function for initializing a FloatParameter that may be optional.
+func NewFloatParameterWithRequired(name, desc string, required bool) *FloatParameter {
+	return &FloatParameter{
+		CommonParameter: CommonParameter{
+			Name:     name,
+			Type:     typeFloat,
+			Desc:     desc,
+			Required: &required,
+		},
+	}
+}
+
 var _ Parameter = &FloatParameter{}
 
 // FloatParameter is a parameter representing the "float" type.
 type FloatParameter struct {
 	CommonParameter `yaml:",inline"`
+	AllowedValues   []float64 `yaml:"allowedValues"`
+	MinValue        *float64  `yaml:"minValue"`
+	MaxValue        *float64  `yaml:"maxValue"`
 }
 
 func (p *FloatParameter) Parse(v any) (any, error) {
@@ -511,9 +980,29 @@ func (p *FloatParameter) Parse(v any) (any, error) {
 		}
 		out = float64(newI)
 	}
+	if err := checkValue(out, p.AllowedValues, p.MinValue, p.MaxValue); err != nil {
+		return nil, err
+	}
 	return out, nil
 }
 
+func (p *FloatParameter) checkConstraints() error {
+	return checkRange(p.MinValue, p.MaxValue, "minValue", "maxValue")
+}
+
+// McpManifest returns the MCP manifest for the FloatParameter.
+func (p *FloatParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Enum = anySlice(p.AllowedValues)
+	if p.MinValue != nil {
+		m.Minimum = *p.MinValue
+	}
+	if p.MaxValue != nil {
+		m.Maximum = *p.MaxValue
+	}
+	return m
+}
+
 func (p *FloatParameter) GetAuthServices() []ParamAuthService {
 	return p.AuthServices
 }
@@ -542,6 +1031,30 @@ func NewBooleanParameterWithAuth(name, desc string, authServices []ParamAuthServ
 	}
 }
 
+// NewBooleanParameterWithDefault is a convenience function for initializing an optional BooleanParameter with a default value.
+func NewBooleanParameterWithDefault(name, desc string, defaultV any) *BooleanParameter {
+	return &BooleanParameter{
+		CommonParameter: CommonParameter{
+			Name:    name,
+			Type:    typeBool,
+			Desc:    desc,
+			Default: defaultV,
+		},
+	}
+}
+
+// NewBooleanParameterWithRequired is a convenience function for initializing a BooleanParameter that may be optional.
+func NewBooleanParameterWithRequired(name, desc string, required bool) *BooleanParameter {
+	return &BooleanParameter{
+		CommonParameter: CommonParameter{
+			Name:     name,
+			Type:     typeBool,
+			Desc:     desc,
+			Required: &required,
+		},
+	}
+}
+
 var _ Parameter = &BooleanParameter{}
 
 // BooleanParameter is a parameter representing the "boolean" type.
@@ -587,23 +1100,54 @@ func NewArrayParameterWithAuth(name, desc string, items Parameter, authServices
 	}
 }
 
+// NewArrayParameterWithDefault is a convenience function for initializing an optional ArrayParameter with a default value.
+func NewArrayParameterWithDefault(name, desc string, items Parameter, defaultV any) *ArrayParameter {
+	return &ArrayParameter{
+		CommonParameter: CommonParameter{
+			Name:    name,
+			Type:    typeArray,
+			Desc:    desc,
+			Default: defaultV,
+		},
+		Items: items,
+	}
+}
+
+// NewArrayParameterWithRequired is a convenience function for initializing an ArrayParameter that may be optional.
+func NewArrayParameterWithRequired(name, desc string, items Parameter, required bool) *ArrayParameter {
+	return &ArrayParameter{
+		CommonParameter: CommonParameter{
+			Name:     name,
+			Type:     typeArray,
+			Desc:     desc,
+			Required: &required,
+		},
+		Items: items,
+	}
+}
+
 var _ Parameter = &ArrayParameter{}
 
 // ArrayParameter is a parameter representing the "array" type.
 type ArrayParameter struct {
 	CommonParameter `yaml:",inline"`
 	Items           Parameter `yaml:"items"`
+	MinItems        *int      `yaml:"minItems"`
+	MaxItems        *int      `yaml:"maxItems"`
 }
 
 func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
 	var rawItem struct {
 		CommonParameter `yaml:",inline"`
 		Items           util.DelayedUnmarshaler `yaml:"items"`
+		MinItems        *int                    `yaml:"minItems"`
+		MaxItems        *int                    `yaml:"maxItems"`
 	}
 	if err := unmarshal(&rawItem); err != nil {
 		return err
 	}
 	p.CommonParameter = rawItem.CommonParameter
+	p.MinItems, p.MaxItems = rawItem.MinItems, rawItem.MaxItems
 	i, err := parseParamFromDelayedUnmarshaler(ctx, &rawItem.Items)
 	if err != nil {
 		return fmt.Errorf("unable to parse 'items' field: %w", err)
@@ -611,6 +1155,9 @@ func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 	if i.GetAuthServices() != nil && len(i.GetAuthServices()) != 0 {
 		return fmt.Errorf("nested items should not have auth services")
 	}
+	if i.IsSensitive() {
+		return fmt.Errorf("nested items cannot be sensitive, mark the array parameter as sensitive instead")
+	}
 	p.Items = i
 
 	return nil
@@ -619,7 +1166,13 @@ func (p *ArrayParameter) UnmarshalYAML(ctx context.Context, unmarshal func(inter
 func (p *ArrayParameter) Parse(v any) (any, error) {
 	arrVal, ok := v.([]any)
 	if !ok {
-		return nil, &ParseTypeError{p.Name, p.Type, arrVal}
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if p.MinItems != nil && len(arrVal) < *p.MinItems {
+		return nil, fmt.Errorf("array has %d item(s), fewer than the minimum of %d", len(arrVal), *p.MinItems)
+	}
+	if p.MaxItems != nil && len(arrVal) > *p.MaxItems {
+		return nil, fmt.Errorf("array has %d item(s), more than the maximum of %d", len(arrVal), *p.MaxItems)
 	}
 	rtn := make([]any, 0, len(arrVal))
 	for idx, val := range arrVal {
@@ -632,38 +1185,264 @@ func (p *ArrayParameter) Parse(v any) (any, error) {
 	return rtn, nil
 }
 
+func (p *ArrayParameter) checkConstraints() error {
+	if p.Items.IsNullable() {
+		// most databases can't bind arrays containing NULL
+		return fmt.Errorf("items cannot be nullable")
+	}
+	return checkRange(p.MinItems, p.MaxItems, "minItems", "maxItems")
+}
+
 func (p *ArrayParameter) GetAuthServices() []ParamAuthService {
 	return p.AuthServices
 }
 
 // Manifest returns the manifest for the ArrayParameter.
 func (p *ArrayParameter) Manifest() ParameterManifest {
-	// only list ParamAuthService names (without fields) in manifest
-	authNames := make([]string, len(p.AuthServices))
-	for i, a := range p.AuthServices {
-		authNames[i] = a.Name
-	}
 	items := p.Items.Manifest()
-	return ParameterManifest{
-		Name:         p.Name,
-		Type:         p.Type,
-		Description:  p.Desc,
-		AuthServices: authNames,
-		Items:        &items,
-	}
+	m := p.CommonParameter.Manifest()
+	m.Items = &items
+	return m
 }
 
 // McpManifest returns the MCP manifest for the ArrayParameter.
 func (p *ArrayParameter) McpManifest() ParameterMcpManifest {
-	// only list ParamAuthService names (without fields) in manifest
-	authNames := make([]string, len(p.AuthServices))
-	for i, a := range p.AuthServices {
-		authNames[i] = a.Name
-	}
 	items := p.Items.McpManifest()
-	return ParameterMcpManifest{
-		Type:        p.Type,
-		Description: p.Desc,
-		Items:       &items,
+	m := p.CommonParameter.McpManifest()
+	m.MinItems, m.MaxItems = p.MinItems, p.MaxItems
+	m.Items = &items
+	return m
+}
+
+// NewObjectParameter is a convenience function for initializing an ObjectParameter with declared properties.
+func NewObjectParameter(name, desc string, properties Parameters) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name: name,
+			Type: typeObject,
+			Desc: desc,
+		},
+		Properties: properties,
+	}
+}
+
+// NewMapParameter is a convenience function for initializing an ObjectParameter whose properties all have the type of "values".
+func NewMapParameter(name, desc string, values Parameter) *ObjectParameter {
+	return &ObjectParameter{
+		CommonParameter: CommonParameter{
+			Name: name,
+			Type: typeObject,
+			Desc: desc,
+		},
+		AdditionalProperties: values,
+	}
+}
+
+var _ Parameter = &ObjectParameter{}
+
+// ObjectParameter is a parameter representing the "object" type.
+type ObjectParameter struct {
+	CommonParameter `yaml:",inline"`
+	// Properties are the declared fields of the object.
+	Properties Parameters `yaml:"properties"`
+	// AdditionalProperties is the type of the values of undeclared fields,
+	// which are rejected if it is not set.
+	AdditionalProperties Parameter `yaml:"additionalProperties"`
+}
+
+func (p *ObjectParameter) UnmarshalYAML(ctx context.Context, unmarshal func(interface{}) error) error {
+	var rawItem struct {
+		CommonParameter      `yaml:",inline"`
+		Properties           []util.DelayedUnmarshaler `yaml:"properties"`
+		AdditionalProperties *util.DelayedUnmarshaler  `yaml:"additionalProperties"`
+	}
+	if err := unmarshal(&rawItem); err != nil {
+		return err
+	}
+	p.CommonParameter = rawItem.CommonParameter
+	if len(rawItem.Properties) == 0 && rawItem.AdditionalProperties == nil {
+		return fmt.Errorf("object parameter %q must have 'properties' or 'additionalProperties'", p.Name)
+	}
+	for _, u := range rawItem.Properties {
+		prop, err := parseParamFromDelayedUnmarshaler(ctx, &u)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'properties' field: %w", err)
+		}
+		if len(prop.GetAuthServices()) != 0 {
+			return fmt.Errorf("nested properties should not have auth services")
+		}
+		if prop.IsSensitive() {
+			return fmt.Errorf("nested properties cannot be sensitive, mark the object parameter as sensitive instead")
+		}
+		if p.declaredProperty(prop.GetName()) != nil {
+			return fmt.Errorf("duplicate property %q", prop.GetName())
+		}
+		p.Properties = append(p.Properties, prop)
+	}
+	if rawItem.AdditionalProperties != nil {
+		v, err := parseParamFromDelayedUnmarshaler(ctx, rawItem.AdditionalProperties)
+		if err != nil {
+			return fmt.Errorf("unable to parse 'additionalProperties' field: %w", err)
+		}
+		if len(v.GetAuthServices()) != 0 {
+			return fmt.Errorf("nested properties should not have auth services")
+		}
+		if v.IsSensitive() {
+			return fmt.Errorf("nested properties cannot be sensitive, mark the object parameter as sensitive instead")
+		}
+		p.AdditionalProperties = v
+	}
+	return nil
+}
+
+// Parse checks the value "v" as an object, parsing each of its properties.
+// Omitted optional properties take their default.
+func (p *ObjectParameter) Parse(v any) (any, error) {
+	objVal, ok := v.(map[string]any)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	rtn := make(map[string]any, len(objVal))
+	for _, prop := range p.Properties {
+		name := prop.GetName()
+		val, ok := objVal[name]
+		if !ok {
+			if prop.IsRequired() {
+				return nil, fmt.Errorf("property %q is required", name)
+			}
+			rtn[name] = prop.GetDefault()
+			continue
+		}
+		if val == nil && prop.IsNullable() {
+			rtn[name] = nil
+			continue
+		}
+		newV, err := prop.Parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse property %q: %w", name, err)
+		}
+		rtn[name] = newV
+	}
+	for name, val := range objVal {
+		if p.declaredProperty(name) != nil {
+			continue
+		}
+		if p.AdditionalProperties == nil {
+			return nil, fmt.Errorf("unknown property %q", name)
+		}
+		if val == nil && p.AdditionalProperties.IsNullable() {
+			rtn[name] = nil
+			continue
+		}
+		newV, err := p.AdditionalProperties.Parse(val)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse property %q: %w", name, err)
+		}
+		rtn[name] = newV
+	}
+	return rtn, nil
+}
+
+// Property returns the parameter describing the property "name" of the
+// object, or nil if there is no such property.
+func (p *ObjectParameter) Property(name string) Parameter {
+	if prop := p.declaredProperty(name); prop != nil {
+		return prop
+	}
+	return p.AdditionalProperties
+}
+
+func (p *ObjectParameter) declaredProperty(name string) Parameter {
+	for _, prop := range p.Properties {
+		if prop.GetName() == name {
+			return prop
+		}
+	}
+	return nil
+}
+
+// PropertyNames returns the names of the properties of a parsed value of the
+// object, the declared properties in order followed by any additional
+// properties sorted by name. Sources use it to bind objects as structs.
+func (p *ObjectParameter) PropertyNames(v map[string]any) []string {
+	names := make([]string, 0, len(p.Properties))
+	for _, prop := range p.Properties {
+		names = append(names, prop.GetName())
+	}
+	var extra []string
+	for name := range v {
+		if !slices.Contains(names, name) {
+			extra = append(extra, name)
+		}
+	}
+	slices.Sort(extra)
+	return append(names, extra...)
+}
+
+func (p *ObjectParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+// Manifest returns the manifest for the ObjectParameter.
+func (p *ObjectParameter) Manifest() ParameterManifest {
+	m := p.CommonParameter.Manifest()
+	for _, prop := range p.Properties {
+		m.Properties = append(m.Properties, prop.Manifest())
+	}
+	if p.AdditionalProperties != nil {
+		values := p.AdditionalProperties.Manifest()
+		m.AdditionalProperties = &values
+	}
+	return m
+}
+
+// McpManifest returns the MCP manifest for the ObjectParameter.
+func (p *ObjectParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	if len(p.Properties) > 0 {
+		schema := p.Properties.McpManifest()
+		m.Properties = schema.Properties
+		m.Required = schema.Required
+	}
+	m.AdditionalProperties = false
+	if p.AdditionalProperties != nil {
+		m.AdditionalProperties = p.AdditionalProperties.McpManifest()
+	}
+	return m
+}
+
+// checkValue checks a number against the allowed values and bounds of a
+// parameter.
+func checkValue[T int | float64](v T, allowed []T, minV, maxV *T) error {
+	if len(allowed) > 0 && !slices.Contains(allowed, v) {
+		return fmt.Errorf("%v is not one of the allowed values %v", v, allowed)
+	}
+	if minV != nil && v < *minV {
+		return fmt.Errorf("%v is less than the minimum value of %v", v, *minV)
+	}
+	if maxV != nil && v > *maxV {
+		return fmt.Errorf("%v is greater than the maximum value of %v", v, *maxV)
+	}
+	return nil
+}
+
+// checkRange checks that the lower bound of a constraint is not above its
+// upper bound.
+func checkRange[T int | float64](minV, maxV *T, minName, maxName string) error {
+	if minV != nil && maxV != nil && *minV > *maxV {
+		return fmt.Errorf("%s %v is greater than %s %v", minName, *minV, maxName, *maxV)
+	}
+	return nil
+}
+
+// anySlice converts allowed values to an "enum" of a JSON Schema.
+func anySlice[T any](values []T) []any {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]any, len(values))
+	for i, v := range values {
+		out[i] = v
 	}
+	return out
 }
@@ -17,8 +17,12 @@ package tools_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
 	"math"
 	"reflect"
+	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
@@ -808,22 +812,22 @@ func TestParamManifest(t *testing.T) {
 		{
 			name: "string",
 			in:   tools.NewStringParameter("foo-string", "bar"),
-			want: tools.ParameterManifest{Name: "foo-string", Type: "string", Description: "bar", AuthServices: []string{}},
+			want: tools.ParameterManifest{Name: "foo-string", Type: "string", Description: "bar", Required: true, AuthServices: []string{}},
 		},
 		{
 			name: "int",
 			in:   tools.NewIntParameter("foo-int", "bar"),
-			want: tools.ParameterManifest{Name: "foo-int", Type: "integer", Description: "bar", AuthServices: []string{}},
+			want: tools.ParameterManifest{Name: "foo-int", Type: "integer", Description: "bar", Required: true, AuthServices: []string{}},
 		},
 		{
 			name: "float",
 			in:   tools.NewFloatParameter("foo-float", "bar"),
-			want: tools.ParameterManifest{Name: "foo-float", Type: "float", Description: "bar", AuthServices: []string{}},
+			want: tools.ParameterManifest{Name: "foo-float", Type: "float", Description: "bar", Required: true, AuthServices: []string{}},
 		},
 		{
 			name: "boolean",
 			in:   tools.NewBooleanParameter("foo-bool", "bar"),
-			want: tools.ParameterManifest{Name: "foo-bool", Type: "boolean", Description: "bar", AuthServices: []string{}},
+			want: tools.ParameterManifest{Name: "foo-bool", Type: "boolean", Description: "bar", Required: true, AuthServices: []string{}},
 		},
 		{
 			name: "array",
@@ -832,8 +836,9 @@ func TestParamManifest(t *testing.T) {
 				Name:         "foo-array",
 				Type:         "array",
 				Description:  "bar",
+				Required:     true,
 				AuthServices: []string{},
-				Items:        &tools.ParameterManifest{Name: "foo-string", Type: "string", Description: "bar", AuthServices: []string{}},
+				Items:        &tools.ParameterManifest{Name: "foo-string", Type: "string", Description: "bar", Required: true, AuthServices: []string{}},
 			},
 		},
 	}
@@ -959,6 +964,32 @@ func TestFailParametersUnmarshal(t *testing.T) {
 			},
 			err: "unable to parse as \"array\": unable to parse 'items' field: unable to parse as \"string\": Key: 'CommonParameter.Name' Error:Field validation for 'Name' failed on the 'required' tag",
 		},
+		{
+			name: "object parameter without properties",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+				},
+			},
+			err: "unable to parse as \"object\": object parameter \"my_object\" must have 'properties' or 'additionalProperties'",
+		},
+		{
+			name: "object parameter with duplicate properties",
+			in: []map[string]any{
+				{
+					"name":        "my_object",
+					"type":        "object",
+					"description": "this param is an object",
+					"properties": []map[string]string{
+						{"name": "a", "type": "string", "description": "a"},
+						{"name": "a", "type": "integer", "description": "a"},
+					},
+				},
+			},
+			err: "unable to parse as \"object\": duplicate property \"a\"",
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
@@ -980,3 +1011,750 @@ func TestFailParametersUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestOptionalParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: limit
+  type: integer
+  description: Max rows.
+  default: 10
+- name: ratio
+  type: float
+  description: A ratio.
+  default: 1
+- name: customer
+  type: string
+  description: The customer.
+  required: false
+- name: tags
+  type: array
+  description: Tags.
+  default: [a, b]
+  items:
+    name: tag
+    type: string
+    description: A tag.
+- name: id
+  type: integer
+  description: The ID.
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	want := tools.Parameters{
+		tools.NewIntParameterWithDefault("limit", "Max rows.", 10),
+		tools.NewFloatParameterWithDefault("ratio", "A ratio.", 1.0),
+		tools.NewStringParameterWithRequired("customer", "The customer.", false),
+		tools.NewArrayParameterWithDefault("tags", "Tags.", tools.NewStringParameter("tag", "A tag."), []any{"a", "b"}),
+		tools.NewIntParameter("id", "The ID."),
+	}
+	if diff := cmp.Diff(want, params); diff != "" {
+		t.Fatalf("incorrect parse (-want +got):\n%s", diff)
+	}
+
+	got, err := tools.ParseParams(params, map[string]any{"id": json.Number("7"), "limit": json.Number("3")}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantValues := tools.ParamValues{
+		{Name: "limit", Value: 3},
+		{Name: "ratio", Value: 1.0},
+		{Name: "customer", Value: nil},
+		{Name: "tags", Value: []any{"a", "b"}},
+		{Name: "id", Value: 7},
+	}
+	if diff := cmp.Diff(wantValues, got); diff != "" {
+		t.Fatalf("incorrect values (-want +got):\n%s", diff)
+	}
+	if _, err := tools.ParseParams(params, map[string]any{}, nil); err == nil || err.Error() != `parameter "id" is required` {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	schema := params.McpManifest()
+	if diff := cmp.Diff([]string{"id"}, schema.Required); diff != "" {
+		t.Fatalf("incorrect required parameters (-want +got):\n%s", diff)
+	}
+	if got := schema.Properties["limit"].Default; got != 10 {
+		t.Fatalf("incorrect default in MCP manifest: got %v", got)
+	}
+	if m := params[2].Manifest(); m.Required || m.Default != nil {
+		t.Fatalf("incorrect manifest: %+v", m)
+	}
+}
+
+func TestFailOptionalParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "default of wrong type",
+			in:   "- {name: limit, type: integer, description: Max rows., default: ten}",
+			err:  `invalid default for parameter "limit": "ten" not type "integer"`,
+		},
+		{
+			name: "required with default",
+			in:   "- {name: limit, type: integer, description: Max rows., required: true, default: 10}",
+			err:  `parameter "limit" is required and cannot have a default`,
+		},
+		{
+			name: "auth parameter with default",
+			in:   "- {name: email, type: string, description: Email., default: a, authServices: [{name: google, field: email}]}",
+			err:  `parameter "email" is read from auth services and cannot have a default`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var got tools.Parameters
+			err := yaml.UnmarshalContext(ctx, []byte(tc.in), &got)
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("unexpected error: got %v, want %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestParameterConstraints(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: status
+  type: string
+  description: The status.
+  allowedValues: [open, closed]
+- name: code
+  type: string
+  description: The code.
+  pattern: ^[A-Z]{3}$
+  minLength: 3
+  maxLength: 3
+- name: limit
+  type: integer
+  description: Max rows.
+  minValue: 1
+  maxValue: 100
+- name: ratio
+  type: float
+  description: A ratio.
+  allowedValues: [0.5, 1]
+- name: tags
+  type: array
+  description: Tags.
+  minItems: 1
+  maxItems: 2
+  items:
+    name: tag
+    type: string
+    description: A tag.
+    allowedValues: [a, b]
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+
+	valid := map[string]any{
+		"status": "open",
+		"code":   "ABC",
+		"limit":  json.Number("100"),
+		"ratio":  json.Number("0.5"),
+		"tags":   []any{"a", "b"},
+	}
+	if _, err := tools.ParseParams(params, valid, nil); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	tcs := []struct {
+		name  string
+		param string
+		value any
+		err   string
+	}{
+		{name: "not allowed string", param: "status", value: "pending", err: `"pending" is not one of the allowed values ["open" "closed"]`},
+		{name: "too short", param: "code", value: "AB", err: `"AB" is shorter than the minimum length of 3`},
+		{name: "too long", param: "code", value: "ABCD", err: `"ABCD" is longer than the maximum length of 3`},
+		{name: "pattern mismatch", param: "code", value: "abc", err: `"abc" does not match the pattern "^[A-Z]{3}$"`},
+		{name: "below minimum", param: "limit", value: json.Number("0"), err: "0 is less than the minimum value of 1"},
+		{name: "above maximum", param: "limit", value: json.Number("101"), err: "101 is greater than the maximum value of 100"},
+		{name: "not allowed float", param: "ratio", value: json.Number("0.7"), err: "0.7 is not one of the allowed values [0.5 1]"},
+		{name: "too few items", param: "tags", value: []any{}, err: "array has 0 item(s), fewer than the minimum of 1"},
+		{name: "too many items", param: "tags", value: []any{"a", "b", "a"}, err: "array has 3 item(s), more than the maximum of 2"},
+		{name: "not allowed item", param: "tags", value: []any{"c"}, err: `unable to parse element #0: "c" is not one of the allowed values ["a" "b"]`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			data := maps.Clone(valid)
+			data[tc.param] = tc.value
+			_, err := tools.ParseParams(params, data, nil)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want substring %q", err, tc.err)
+			}
+		})
+	}
+
+	three, one, two := 3, 1, 2
+	schema := params.McpManifest()
+	want := map[string]tools.ParameterMcpManifest{
+		"status": {Type: "string", Description: "The status.", Enum: []any{"open", "closed"}},
+		"code":   {Type: "string", Description: "The code.", Pattern: "^[A-Z]{3}$", MinLength: &three, MaxLength: &three},
+		"limit":  {Type: "integer", Description: "Max rows.", Minimum: 1, Maximum: 100},
+		"ratio":  {Type: "float", Description: "A ratio.", Enum: []any{0.5, 1.0}},
+		"tags": {
+			Type:        "array",
+			Description: "Tags.",
+			MinItems:    &one,
+			MaxItems:    &two,
+			Items:       &tools.ParameterMcpManifest{Type: "string", Description: "A tag.", Enum: []any{"a", "b"}},
+		},
+	}
+	if diff := cmp.Diff(want, schema.Properties); diff != "" {
+		t.Fatalf("incorrect MCP manifest (-want +got):\n%s", diff)
+	}
+}
+
+func TestFailParameterConstraints(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tcs := []struct {
+		name string
+		in   string
+		err  string
+	}{
+		{
+			name: "invalid pattern",
+			in:   "- {name: code, type: string, description: The code., pattern: '[a-'}",
+			err:  `invalid constraints for parameter "code": invalid pattern`,
+		},
+		{
+			name: "minimum above maximum",
+			in:   "- {name: limit, type: integer, description: Max rows., minValue: 10, maxValue: 1}",
+			err:  `invalid constraints for parameter "limit": minValue 10 is greater than maxValue 1`,
+		},
+		{
+			name: "minItems above maxItems",
+			in:   "- {name: tags, type: array, description: Tags., minItems: 3, maxItems: 1, items: {name: tag, type: string, description: A tag.}}",
+			err:  `invalid constraints for parameter "tags": minItems 3 is greater than maxItems 1`,
+		},
+		{
+			name: "default outside range",
+			in:   "- {name: limit, type: integer, description: Max rows., maxValue: 100, default: 500}",
+			err:  `invalid default for parameter "limit": 500 is greater than the maximum value of 100`,
+		},
+		{
+			name: "constraint of another type",
+			in:   "- {name: active, type: boolean, description: Active., allowedValues: [true]}",
+			err:  `unknown field "allowedValues"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			var got tools.Parameters
+			err := yaml.UnmarshalContext(ctx, []byte(tc.in), &got)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want substring %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestObjectParameter(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: address
+  type: object
+  description: The address.
+  properties:
+    - name: city
+      type: string
+      description: The city.
+    - name: zip
+      type: integer
+      description: The zip code.
+      required: false
+    - name: tags
+      type: object
+      description: Tags of the address.
+      default: {}
+      additionalProperties:
+        name: tag
+        type: string
+        description: A tag.
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	tags := tools.NewMapParameter("tags", "Tags of the address.", tools.NewStringParameter("tag", "A tag."))
+	tags.Default = map[string]any{}
+	want := tools.Parameters{
+		tools.NewObjectParameter("address", "The address.", tools.Parameters{
+			tools.NewStringParameter("city", "The city."),
+			tools.NewIntParameterWithRequired("zip", "The zip code.", false),
+			tags,
+		}),
+	}
+	if diff := cmp.Diff(want, params); diff != "" {
+		t.Fatalf("incorrect parse (-want +got):\n%s", diff)
+	}
+
+	got, err := tools.ParseParams(params, map[string]any{
+		"address": map[string]any{"city": "Basel", "tags": map[string]any{"kind": "home"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	wantValues := tools.ParamValues{{Name: "address", Value: map[string]any{
+		"city": "Basel",
+		"zip":  nil,
+		"tags": map[string]any{"kind": "home"},
+	}}}
+	if diff := cmp.Diff(wantValues, got); diff != "" {
+		t.Fatalf("incorrect values (-want +got):\n%s", diff)
+	}
+
+	tcs := []struct {
+		name  string
+		value any
+		err   string
+	}{
+		{name: "not an object", value: "Basel", err: `"Basel" not type "object"`},
+		{name: "missing property", value: map[string]any{"zip": json.Number("4051")}, err: `property "city" is required`},
+		{name: "unknown property", value: map[string]any{"city": "Basel", "street": "Main"}, err: `unknown property "street"`},
+		{name: "wrong property type", value: map[string]any{"city": "Basel", "zip": "4051"}, err: `unable to parse property "zip": "4051" not type "integer"`},
+		{name: "wrong additional property type", value: map[string]any{"city": "Basel", "tags": map[string]any{"kind": json.Number("1")}}, err: `unable to parse property "tags": unable to parse property "kind": "1" not type "string"`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tools.ParseParams(params, map[string]any{"address": tc.value}, nil)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want substring %q", err, tc.err)
+			}
+		})
+	}
+
+	wantMcp := tools.ParameterMcpManifest{
+		Type:        "object",
+		Description: "The address.",
+		Properties: map[string]tools.ParameterMcpManifest{
+			"city": {Type: "string", Description: "The city."},
+			"zip":  {Type: "integer", Description: "The zip code."},
+			"tags": {
+				Type:                 "object",
+				Description:          "Tags of the address.",
+				Default:              map[string]any{},
+				AdditionalProperties: tools.ParameterMcpManifest{Type: "string", Description: "A tag."},
+			},
+		},
+		Required:             []string{"city"},
+		AdditionalProperties: false,
+	}
+	if diff := cmp.Diff(wantMcp, params[0].McpManifest()); diff != "" {
+		t.Fatalf("incorrect MCP manifest (-want +got):\n%s", diff)
+	}
+	m := params[0].Manifest()
+	if len(m.Properties) != 3 || m.Properties[2].AdditionalProperties == nil || m.Properties[2].AdditionalProperties.Type != "string" {
+		t.Fatalf("incorrect manifest: %+v", m)
+	}
+}
+
+func TestParseParamsErrors(t *testing.T) {
+	params := tools.Parameters{
+		tools.NewIntParameter("id", "The id."),
+		tools.NewStringParameter("name", "The name."),
+		tools.NewBooleanParameter("active", "Whether it is active."),
+	}
+	_, err := tools.ParseParams(params, map[string]any{"id": "one", "active": true}, nil)
+	var got tools.ParamErrors
+	if !errors.As(err, &got) {
+		t.Fatalf("expected ParamErrors, got %v", err)
+	}
+	want := []tools.ParamError{
+		{Param: "id", Reason: `"one" not type "integer"`},
+		{Param: "name", Reason: "parameter is required"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("unexpected number of errors: got %d, want %d: %s", len(got), len(want), err)
+	}
+	for i, e := range got {
+		if e.Param != want[i].Param || e.Reason != want[i].Reason {
+			t.Fatalf("unexpected error #%d: got %+v, want %+v", i, *e, want[i])
+		}
+	}
+	wantMsg := `unable to parse value for "id": "one" not type "integer"; parameter "name" is required`
+	if err.Error() != wantMsg {
+		t.Fatalf("unexpected message: got %q, want %q", err.Error(), wantMsg)
+	}
+}
+
+func TestNullableParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: city
+  type: string
+  description: The city, or null for every city.
+  nullable: true
+  allowedValues: [Basel, Zurich]
+- name: limit
+  type: integer
+  description: The maximum number of rows.
+- name: address
+  type: object
+  description: The address.
+  properties:
+    - name: street
+      type: string
+      description: The street.
+      nullable: true
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+
+	data := map[string]any{"city": nil, "limit": 10, "address": map[string]any{"street": nil}}
+	got, err := tools.ParseParams(params, data, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := tools.ParamValues{
+		{Name: "city", Value: nil},
+		{Name: "limit", Value: 10},
+		{Name: "address", Value: map[string]any{"street": nil}},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect values: diff %v", diff)
+	}
+
+	_, err = tools.ParseParams(params, map[string]any{"city": "Basel", "limit": nil, "address": map[string]any{"street": "Main"}}, nil)
+	if err == nil || err.Error() != `parameter "limit" is not nullable` {
+		t.Fatalf("expected null to be rejected, got %v", err)
+	}
+
+	b, err := json.Marshal(params.McpManifest().Properties["city"])
+	if err != nil {
+		t.Fatalf("unable to marshal: %s", err)
+	}
+	var gotSchema map[string]any
+	if err := json.Unmarshal(b, &gotSchema); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	wantSchema := map[string]any{
+		"type":        []any{"string", "null"},
+		"description": "The city, or null for every city.",
+		"enum":        []any{"Basel", "Zurich", nil},
+	}
+	if diff := cmp.Diff(wantSchema, gotSchema); diff != "" {
+		t.Fatalf("incorrect MCP manifest: diff %v", diff)
+	}
+	if m := params[0].Manifest(); !m.Nullable {
+		t.Fatalf("expected the manifest to be nullable")
+	}
+
+	arrayIn := `
+- name: cities
+  type: array
+  description: The cities.
+  items:
+    name: city
+    type: string
+    description: A city.
+    nullable: true
+`
+	err = yaml.UnmarshalContext(ctx, []byte(arrayIn), &params)
+	if err == nil || !strings.Contains(err.Error(), "items cannot be nullable") {
+		t.Fatalf("expected nullable items to be rejected, got %v", err)
+	}
+}
+
+func TestSensitiveParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: email
+  type: string
+  description: The email of the user.
+  sensitive: true
+  pattern: "^[^@]+@example[.]com$"
+- name: pin
+  type: integer
+  description: The PIN of the user.
+  sensitive: true
+  maxValue: 9999
+- name: limit
+  type: integer
+  description: The maximum number of rows.
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+
+	got, err := tools.ParseParams(params, map[string]any{"email": "alice@example.com", "pin": 1234, "limit": 10}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := fmt.Sprintf("%s", got); s != "[{email [REDACTED]} {pin [REDACTED]} {limit 10}]" {
+		t.Fatalf("unexpected formatting: %s", s)
+	}
+
+	// errors of sensitive parameters only name their type
+	_, err = tools.ParseParams(params, map[string]any{"email": "alice@gmail.com", "pin": 12345, "limit": "alice@gmail.com"}, nil)
+	want := `unable to parse value for "email": invalid value for sensitive parameter of type "string"; ` +
+		`unable to parse value for "pin": invalid value for sensitive parameter of type "integer"; ` +
+		`unable to parse value for "limit": "alice@gmail.com" not type "integer"`
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %s", err, want)
+	}
+	var paramErrs tools.ParamErrors
+	if !errors.As(err, &paramErrs) || paramErrs[0].Reason != `invalid value for sensitive parameter of type "string"` {
+		t.Fatalf("unexpected reason: %v", err)
+	}
+
+	claimed := tools.NewIntParameterWithAuth("pin", "The PIN of the user.", []tools.ParamAuthService{{Name: "my-auth", Field: "pin"}})
+	claimed.Sensitive = true
+	_, err = tools.ParseParams(tools.Parameters{claimed}, nil, map[string]map[string]any{"my-auth": {"pin": 1.5}})
+	want = `unable to parse value for "pin": claim "pin" of auth service "my-auth": invalid value for sensitive parameter of type "integer"`
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %s", err, want)
+	}
+
+	withDefault := `
+- name: email
+  type: string
+  description: The email of the user.
+  sensitive: true
+  default: alice@example.com
+`
+	err = yaml.UnmarshalContext(ctx, []byte(withDefault), &params)
+	if err == nil || !strings.Contains(err.Error(), `parameter "email" is sensitive and cannot have a default`) {
+		t.Fatalf("expected a default to be rejected, got %v", err)
+	}
+
+	nested := `
+- name: user
+  type: object
+  description: The user.
+  properties:
+    - name: email
+      type: string
+      description: The email of the user.
+      sensitive: true
+`
+	err = yaml.UnmarshalContext(ctx, []byte(nested), &params)
+	if err == nil || !strings.Contains(err.Error(), "nested properties cannot be sensitive") {
+		t.Fatalf("expected sensitive properties to be rejected, got %v", err)
+	}
+}
+
+func TestExampleParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: date
+  type: string
+  description: The departure date.
+  pattern: "^[0-9]{4}-[0-9]{2}-[0-9]{2}$"
+  examples: ["2025-01-31"]
+- name: tags
+  type: array
+  description: The tags.
+  items:
+    name: tag
+    type: string
+    description: A tag.
+  examples: [[cheap, direct]]
+- name: limit
+  type: integer
+  description: The maximum number of rows.
+  nullable: true
+  examples: [10, null]
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+
+	b, err := json.Marshal(params.McpManifest())
+	if err != nil {
+		t.Fatalf("unable to marshal: %s", err)
+	}
+	var gotSchema struct {
+		Properties map[string]struct {
+			Examples []any `json:"examples"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(b, &gotSchema); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	wantExamples := map[string][]any{
+		"date":  {"2025-01-31"},
+		"tags":  {[]any{"cheap", "direct"}},
+		"limit": {float64(10), nil},
+	}
+	for name, want := range wantExamples {
+		if diff := cmp.Diff(want, gotSchema.Properties[name].Examples); diff != "" {
+			t.Fatalf("incorrect MCP examples of %q: diff %v", name, diff)
+		}
+	}
+
+	b, err = json.Marshal(params.Manifest()[0])
+	if err != nil {
+		t.Fatalf("unable to marshal: %s", err)
+	}
+	if !strings.Contains(string(b), `"examples":["2025-01-31"]`) {
+		t.Fatalf("missing examples in manifest: %s", b)
+	}
+
+	tcs := []struct {
+		desc string
+		in   string
+		err  string
+	}{
+		{
+			desc: "wrong type",
+			in: `
+- name: limit
+  type: integer
+  description: The maximum number of rows.
+  examples: [ten]
+`,
+			err: `invalid example #0 for parameter "limit": "ten" not type "integer"`,
+		},
+		{
+			desc: "constraint",
+			in: `
+- name: date
+  type: string
+  description: The departure date.
+  pattern: "^[0-9]{4}-[0-9]{2}-[0-9]{2}$"
+  examples: ["2025-01-31", "31.01.2025"]
+`,
+			err: `invalid example #1 for parameter "date"`,
+		},
+		{
+			desc: "not nullable",
+			in: `
+- name: limit
+  type: integer
+  description: The maximum number of rows.
+  examples: [null]
+`,
+			err: `invalid example #0 for parameter "limit"`,
+		},
+		{
+			desc: "auth service",
+			in: `
+- name: email
+  type: string
+  description: The email of the user.
+  authServices:
+    - name: my-auth
+      field: email
+  examples: [alice@example.com]
+`,
+			err: `parameter "email" is read from auth services and cannot have examples`,
+		},
+		{
+			desc: "sensitive",
+			in: `
+- name: email
+  type: string
+  description: The email of the user.
+  sensitive: true
+  examples: [alice@example.com]
+`,
+			err: `parameter "email" is sensitive and cannot have examples`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			var params tools.Parameters
+			err := yaml.UnmarshalContext(ctx, []byte(tc.in), &params)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestCheckExamples(t *testing.T) {
+	user := tools.NewStringParameterWithAuth("user", "The user.", []tools.ParamAuthService{{Name: "my-auth", Field: "email"}})
+	limit := tools.NewIntParameterWithRequired("limit", "The maximum number of rows.", false)
+	pin := tools.NewIntParameter("pin", "The PIN of the user.")
+	pin.Sensitive = true
+	params := tools.Parameters{tools.NewStringParameter("city", "The city."), limit, user, pin}
+
+	tcs := []struct {
+		desc     string
+		examples []map[string]any
+		err      string
+	}{
+		{
+			desc:     "valid",
+			examples: []map[string]any{{"city": "Basel"}, {"city": "Zurich", "limit": 5}},
+		},
+		{
+			desc:     "unknown parameter",
+			examples: []map[string]any{{"city": "Basel", "country": "CH"}},
+			err:      `invalid example #0: unknown parameter "country"`,
+		},
+		{
+			desc:     "missing required parameter",
+			examples: []map[string]any{{"city": "Basel"}, {"limit": 5}},
+			err:      `invalid example #1: parameter "city" is required`,
+		},
+		{
+			desc:     "auth parameter",
+			examples: []map[string]any{{"city": "Basel", "user": "alice@example.com"}},
+			err:      `invalid example #0: parameter "user" is read from auth services`,
+		},
+		{
+			desc:     "sensitive parameter",
+			examples: []map[string]any{{"city": "Basel", "pin": 1234}},
+			err:      `invalid example #0: parameter "pin" is sensitive`,
+		},
+		{
+			desc:     "invalid value",
+			examples: []map[string]any{{"city": "Basel", "limit": "five"}},
+			err:      `invalid example #0: unable to parse value for "limit": "five" not type "integer"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tools.CheckExamples(params, tc.examples)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tc.err {
+				t.Fatalf("expected error %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
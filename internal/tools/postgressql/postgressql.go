@@ -16,18 +16,36 @@ package postgressql
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
 
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/alloydbpg"
 	"github.com/googleapis/genai-toolbox/internal/sources/cloudsqlpg"
 	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
 	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 const ToolKind string = "postgres-sql"
 
+func init() {
+	tools.Register(ToolKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 type compatibleSource interface {
 	PostgresPool() *pgxpool.Pool
 }
@@ -40,22 +58,77 @@ var _ compatibleSource = &postgres.Source{}
 var compatibleSources = [...]string{alloydbpg.SourceKind, cloudsqlpg.SourceKind, postgres.SourceKind}
 
 type Config struct {
-	Name         string           `yaml:"name" validate:"required"`
-	Kind         string           `yaml:"kind" validate:"required"`
-	Source       string           `yaml:"source" validate:"required"`
-	Description  string           `yaml:"description" validate:"required"`
-	Statement    string           `yaml:"statement" validate:"required"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name               string           `yaml:"name" validate:"required"`
+	Kind               string           `yaml:"kind" validate:"required"`
+	Source             string           `yaml:"source" validate:"required"`
+	Description        string           `yaml:"description" validate:"required"`
+	Statement          string           `yaml:"statement" validate:"required"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	// SessionSettings maps a Postgres setting to a claim, in the form
+	// "<authService>.<field>", that is applied with SET LOCAL semantics
+	// before the statement runs.
+	SessionSettings map[string]string `yaml:"sessionSettings"`
+	Examples        []map[string]any  `yaml:"examples"`
 }
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.ReferencingToolConfig = Config{}
+
+// sessionSetting is a Postgres setting populated from an auth service claim.
+type sessionSetting struct {
+	Name  string
+	Claim tools.ParamAuthService
+}
+
+// parseSessionSettings validates the configured settings and returns them in
+// a deterministic order.
+func parseSessionSettings(settings map[string]string) ([]sessionSetting, error) {
+	out := make([]sessionSetting, 0, len(settings))
+	for name, claim := range settings {
+		if name == "" {
+			return nil, fmt.Errorf("session setting name must not be empty")
+		}
+		authService, field, ok := strings.Cut(claim, ".")
+		if !ok || authService == "" || field == "" {
+			return nil, fmt.Errorf("invalid claim %q for session setting %q: must be in the form <authService>.<field>", claim, name)
+		}
+		out = append(out, sessionSetting{Name: name, Claim: tools.ParamAuthService{Name: authService, Field: field}})
+	}
+	slices.SortFunc(out, func(a, b sessionSetting) int { return strings.Compare(a.Name, b.Name) })
+	return out, nil
+}
 
 func (cfg Config) ToolConfigKind() string {
 	return ToolKind
 }
 
+func (cfg Config) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{
+		Source:                cfg.Source,
+		CompatibleSourceKinds: compatibleSources[:],
+		AuthRequired:          cfg.AuthRequired,
+		Parameters:            slices.Concat(cfg.Parameters, cfg.TemplateParameters),
+		AuthServices:          sessionSettingAuthServices(cfg.SessionSettings),
+	}
+}
+
+// sessionSettingAuthServices returns the auth services that session settings
+// read claims from, sorted and without duplicates. Invalid claims are reported
+// by Initialize.
+func sessionSettingAuthServices(settings map[string]string) []string {
+	var out []string
+	for _, claim := range settings {
+		if authService, _, ok := strings.Cut(claim, "."); ok && authService != "" {
+			out = append(out, authService)
+		}
+	}
+	slices.Sort(out)
+	return slices.Compact(out)
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -69,22 +142,40 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", ToolKind, compatibleSources)
 	}
 
+	if err := tools.CheckTemplateParameters(cfg.Statement, cfg.Parameters, cfg.TemplateParameters); err != nil {
+		return nil, err
+	}
+	allParameters := slices.Concat(cfg.Parameters, cfg.TemplateParameters)
+
+	sessionSettings, err := parseSessionSettings(cfg.SessionSettings)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tools.CheckExamples(allParameters, cfg.Examples); err != nil {
+		return nil, err
+	}
+	inputSchema := allParameters.McpManifest()
+	inputSchema.Examples = cfg.Examples
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
-		InputSchema: cfg.Parameters.McpManifest(),
+		InputSchema: inputSchema,
 	}
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         ToolKind,
-		Parameters:   cfg.Parameters,
-		Statement:    cfg.Statement,
-		AuthRequired: cfg.AuthRequired,
-		Pool:         s.PostgresPool(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest()},
-		mcpManifest:  mcpManifest,
+		Name:               cfg.Name,
+		Kind:               ToolKind,
+		Parameters:         cfg.Parameters,
+		TemplateParameters: cfg.TemplateParameters,
+		Statement:          cfg.Statement,
+		AuthRequired:       cfg.AuthRequired,
+		Pool:               s.PostgresPool(),
+		sessionSettings:    sessionSettings,
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), Examples: cfg.Examples},
+		mcpManifest:        mcpManifest,
 	}
 	return t, nil
 }
@@ -93,24 +184,126 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 var _ tools.Tool = Tool{}
 
 type Tool struct {
-	Name         string           `yaml:"name"`
-	Kind         string           `yaml:"kind"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name               string           `yaml:"name"`
+	Kind               string           `yaml:"kind"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+
+	Pool            *pgxpool.Pool
+	Statement       string
+	sessionSettings []sessionSetting
+	manifest        tools.Manifest
+	mcpManifest     tools.McpManifest
+}
 
-	Pool        *pgxpool.Pool
-	Statement   string
-	manifest    tools.Manifest
-	mcpManifest tools.McpManifest
+// bindValues returns the values to bind to the statement. Objects are bound as
+// JSON text, which Postgres converts to json or jsonb.
+func bindValues(params tools.ParamValues) ([]any, error) {
+	values := params.AsSlice()
+	for i, v := range values {
+		var err error
+		if values[i], err = jsonValue(v); err != nil {
+			return nil, fmt.Errorf("unable to encode parameter %q: %w", params[i].Name, err)
+		}
+	}
+	return values, nil
+}
+
+func jsonValue(v any) (any, error) {
+	switch v := v.(type) {
+	case map[string]any:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case []any:
+		out := make([]any, len(v))
+		for i, item := range v {
+			var err error
+			if out[i], err = jsonValue(item); err != nil {
+				return nil, err
+			}
+		}
+		return out, nil
+	}
+	return v, nil
 }
 
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
-	sliceParams := params.AsSlice()
-	results, err := t.Pool.Query(ctx, t.Statement, sliceParams...)
+	statement, params, err := tools.ResolveTemplateParams(t.Statement, t.TemplateParameters, params, tools.DoubleQuotes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve statement: %w", err)
+	}
+	sliceParams, err := bindValues(params)
+	if err != nil {
+		return nil, err
+	}
+	if len(t.sessionSettings) == 0 {
+		results, err := t.Pool.Query(ctx, statement, sliceParams...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to execute query: %w", err)
+		}
+		return collectRows(results)
+	}
+
+	// resolve every setting before opening a transaction
+	claimsMap := util.ClaimsFromContext(ctx)
+	values := make([]string, len(t.sessionSettings))
+	for i, setting := range t.sessionSettings {
+		v, err := tools.ParseFromAuthService([]tools.ParamAuthService{setting.Claim}, claimsMap)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve session setting %q: %w", setting.Name, err)
+		}
+		if values[i], err = settingValue(v); err != nil {
+			return nil, fmt.Errorf("unable to resolve session setting %q: %w", setting.Name, err)
+		}
+	}
+
+	tx, err := t.Pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	for i, setting := range t.sessionSettings {
+		// set_config with is_local=true is equivalent to SET LOCAL, but accepts bind parameters
+		if _, err := tx.Exec(ctx, "SELECT set_config($1, $2, true)", setting.Name, values[i]); err != nil {
+			return nil, fmt.Errorf("unable to apply session setting %q: %w", setting.Name, err)
+		}
+	}
+	results, err := tx.Query(ctx, statement, sliceParams...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
+	out, err := collectRows(results)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("unable to commit transaction: %w", err)
+	}
+	return out, nil
+}
+
+// settingValue returns the value of a session setting for a claim. String
+// claims are set as is, and any other claim, such as a number or a list of
+// groups, as JSON.
+func settingValue(claim any) (string, error) {
+	if s, ok := claim.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(claim)
+	if err != nil {
+		return "", fmt.Errorf("unable to encode claim as JSON: %w", err)
+	}
+	return string(b), nil
+}
 
+// collectRows reads all rows into a list of column name to value maps.
+func collectRows(results pgx.Rows) ([]any, error) {
+	defer results.Close()
 	fields := results.FieldDescriptions()
 
 	var out []any
@@ -125,12 +318,15 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 		}
 		out = append(out, vMap)
 	}
+	if err := results.Err(); err != nil {
+		return nil, fmt.Errorf("unable to execute query: %w", err)
+	}
 
 	return out, nil
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
-	return tools.ParseParams(t.Parameters, data, claims)
+	return tools.ParseParams(slices.Concat(t.Parameters, t.TemplateParameters), data, claims)
 }
 
 func (t Tool) Manifest() tools.Manifest {
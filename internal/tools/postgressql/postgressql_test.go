@@ -15,11 +15,14 @@
 package postgressql_test
 
 import (
+	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/postgres"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/tools/postgressql"
@@ -74,6 +77,33 @@ func TestParseFromYamlPostgres(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc: "with session settings",
+			in: `
+			tools:
+				example_tool:
+					kind: postgres-sql
+					source: my-pg-instance
+					description: some description
+					statement: |
+						SELECT * FROM orders;
+					authRequired:
+						- my-google-auth-service
+					sessionSettings:
+						app.user_email: my-google-auth-service.email
+			`,
+			want: server.ToolConfigs{
+				"example_tool": postgressql.Config{
+					Name:            "example_tool",
+					Kind:            postgressql.ToolKind,
+					Source:          "my-pg-instance",
+					Description:     "some description",
+					Statement:       "SELECT * FROM orders;\n",
+					AuthRequired:    []string{"my-google-auth-service"},
+					SessionSettings: map[string]string{"app.user_email": "my-google-auth-service.email"},
+				},
+			},
+		},
 	}
 	for _, tc := range tcs {
 		t.Run(tc.desc, func(t *testing.T) {
@@ -92,3 +122,42 @@ func TestParseFromYamlPostgres(t *testing.T) {
 	}
 
 }
+
+func TestFailInitializeSessionSettings(t *testing.T) {
+	srcs := map[string]sources.Source{"my-pg-instance": &postgres.Source{Name: "my-pg-instance", Kind: postgres.SourceKind}}
+	tcs := []struct {
+		desc     string
+		settings map[string]string
+		err      string
+	}{
+		{
+			desc:     "missing field",
+			settings: map[string]string{"app.user_email": "my-google-auth-service"},
+			err:      `invalid claim "my-google-auth-service" for session setting "app.user_email"`,
+		},
+		{
+			desc:     "empty auth service",
+			settings: map[string]string{"app.user_email": ".email"},
+			err:      `invalid claim ".email" for session setting "app.user_email"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			cfg := postgressql.Config{
+				Name:            "example_tool",
+				Kind:            postgressql.ToolKind,
+				Source:          "my-pg-instance",
+				Description:     "some description",
+				Statement:       "SELECT 1;",
+				SessionSettings: tc.settings,
+			}
+			_, err := cfg.Initialize(srcs)
+			if err == nil {
+				t.Fatalf("expect initialization to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want substring %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -17,17 +17,36 @@ package spanner
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"cloud.google.com/go/civil"
 	"cloud.google.com/go/spanner"
+	sppb "cloud.google.com/go/spanner/apiv1/spannerpb"
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	spannerdb "github.com/googleapis/genai-toolbox/internal/sources/spanner"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 const ToolKind string = "spanner-sql"
 
+func init() {
+	tools.Register(ToolKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 type compatibleSource interface {
 	SpannerClient() *spanner.Client
 	DatabaseDialect() string
@@ -39,22 +58,34 @@ var _ compatibleSource = &spannerdb.Source{}
 var compatibleSources = [...]string{spannerdb.SourceKind}
 
 type Config struct {
-	Name         string           `yaml:"name" validate:"required"`
-	Kind         string           `yaml:"kind" validate:"required"`
-	Source       string           `yaml:"source" validate:"required"`
-	Description  string           `yaml:"description" validate:"required"`
-	Statement    string           `yaml:"statement" validate:"required"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name               string           `yaml:"name" validate:"required"`
+	Kind               string           `yaml:"kind" validate:"required"`
+	Source             string           `yaml:"source" validate:"required"`
+	Description        string           `yaml:"description" validate:"required"`
+	Statement          string           `yaml:"statement" validate:"required"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	Examples           []map[string]any `yaml:"examples"`
 }
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.ReferencingToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return ToolKind
 }
 
+func (cfg Config) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{
+		Source:                cfg.Source,
+		CompatibleSourceKinds: compatibleSources[:],
+		AuthRequired:          cfg.AuthRequired,
+		Parameters:            slices.Concat(cfg.Parameters, cfg.TemplateParameters),
+	}
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -68,23 +99,35 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", ToolKind, compatibleSources)
 	}
 
+	if err := tools.CheckTemplateParameters(cfg.Statement, cfg.Parameters, cfg.TemplateParameters); err != nil {
+		return nil, err
+	}
+	allParameters := slices.Concat(cfg.Parameters, cfg.TemplateParameters)
+
+	if err := tools.CheckExamples(allParameters, cfg.Examples); err != nil {
+		return nil, err
+	}
+	inputSchema := allParameters.McpManifest()
+	inputSchema.Examples = cfg.Examples
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
-		InputSchema: cfg.Parameters.McpManifest(),
+		InputSchema: inputSchema,
 	}
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         ToolKind,
-		Parameters:   cfg.Parameters,
-		Statement:    cfg.Statement,
-		AuthRequired: cfg.AuthRequired,
-		Client:       s.SpannerClient(),
-		dialect:      s.DatabaseDialect(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest()},
-		mcpManifest:  mcpManifest,
+		Name:               cfg.Name,
+		Kind:               ToolKind,
+		Parameters:         cfg.Parameters,
+		TemplateParameters: cfg.TemplateParameters,
+		Statement:          cfg.Statement,
+		AuthRequired:       cfg.AuthRequired,
+		Client:             s.SpannerClient(),
+		dialect:            s.DatabaseDialect(),
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), Examples: cfg.Examples},
+		mcpManifest:        mcpManifest,
 	}
 	return t, nil
 }
@@ -93,10 +136,11 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 var _ tools.Tool = Tool{}
 
 type Tool struct {
-	Name         string           `yaml:"name"`
-	Kind         string           `yaml:"kind"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name               string           `yaml:"name"`
+	Kind               string           `yaml:"kind"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 
 	Client      *spanner.Client
 	dialect     string
@@ -116,8 +160,130 @@ func getMapParams(params tools.ParamValues, dialect string) (map[string]interfac
 	}
 }
 
+// identifierQuotes returns the quotes of identifiers in the dialect.
+func identifierQuotes(dialect string) tools.IdentifierQuotes {
+	if strings.ToLower(dialect) == "postgresql" {
+		return tools.DoubleQuotes
+	}
+	return tools.Backticks
+}
+
+// nullValue returns a typed NULL for an omitted optional parameter, since
+// Spanner cannot infer the type of an untyped one.
+func nullValue(p tools.Parameter) any {
+	switch p.GetType() {
+	case "string":
+		return spanner.NullString{}
+	case "integer":
+		return spanner.NullInt64{}
+	case "float":
+		return spanner.NullFloat64{}
+	case "boolean":
+		return spanner.NullBool{}
+	case "date":
+		return spanner.NullDate{}
+	case "timestamp":
+		return spanner.NullTime{}
+	case "duration":
+		return typedValue(p, nil)
+	case "array":
+		if a, ok := p.(*tools.ArrayParameter); ok {
+			switch a.Items.GetType() {
+			case "string":
+				return []string(nil)
+			case "integer":
+				return []int64(nil)
+			case "float":
+				return []float64(nil)
+			case "boolean":
+				return []bool(nil)
+			}
+		}
+	}
+	return nil
+}
+
+// typedValue returns "v" with the Spanner type of the parameter "p", so that
+// objects can be bound as STRUCTs and durations as INTERVALs.
+func typedValue(p tools.Parameter, v any) spanner.GenericColumnValue {
+	rtn := spanner.GenericColumnValue{Value: structpb.NewNullValue()}
+	switch p := p.(type) {
+	case *tools.ObjectParameter:
+		obj, _ := v.(map[string]any)
+		structType := &sppb.StructType{}
+		values := &structpb.ListValue{}
+		for _, name := range p.PropertyNames(obj) {
+			field := typedValue(p.Property(name), obj[name])
+			structType.Fields = append(structType.Fields, &sppb.StructType_Field{Name: name, Type: field.Type})
+			values.Values = append(values.Values, field.Value)
+		}
+		rtn.Type = &sppb.Type{Code: sppb.TypeCode_STRUCT, StructType: structType}
+		if obj != nil {
+			rtn.Value = structpb.NewListValue(values)
+		}
+		return rtn
+	case *tools.ArrayParameter:
+		rtn.Type = &sppb.Type{Code: sppb.TypeCode_ARRAY, ArrayElementType: typedValue(p.Items, nil).Type}
+		if items, ok := v.([]any); ok {
+			values := &structpb.ListValue{}
+			for _, item := range items {
+				values.Values = append(values.Values, typedValue(p.Items, item).Value)
+			}
+			rtn.Value = structpb.NewListValue(values)
+		}
+		return rtn
+	}
+	switch v := v.(type) {
+	case string:
+		rtn.Value = structpb.NewStringValue(v)
+	case int:
+		// INT64 values are encoded as strings
+		rtn.Value = structpb.NewStringValue(strconv.Itoa(v))
+	case float64:
+		rtn.Value = structpb.NewNumberValue(v)
+	case bool:
+		rtn.Value = structpb.NewBoolValue(v)
+	case time.Time, time.Duration:
+		// dates, timestamps and intervals are encoded as strings
+		rtn.Value = structpb.NewStringValue(tools.FormatValue(p, v).(string))
+	}
+	codes := map[string]sppb.TypeCode{
+		"string":    sppb.TypeCode_STRING,
+		"integer":   sppb.TypeCode_INT64,
+		"float":     sppb.TypeCode_FLOAT64,
+		"boolean":   sppb.TypeCode_BOOL,
+		"date":      sppb.TypeCode_DATE,
+		"timestamp": sppb.TypeCode_TIMESTAMP,
+		"duration":  sppb.TypeCode_INTERVAL,
+	}
+	rtn.Type = &sppb.Type{Code: codes[p.GetType()]}
+	return rtn
+}
+
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
-	mapParams, err := getMapParams(params, t.dialect)
+	statement, params, err := tools.ResolveTemplateParams(t.Statement, t.TemplateParameters, params, identifierQuotes(t.dialect))
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve statement: %w", err)
+	}
+	typed := make(tools.ParamValues, len(params))
+	for i, p := range params {
+		if p.Value == nil {
+			p.Value = nullValue(t.Parameters[i])
+		}
+		if obj, ok := t.Parameters[i].(*tools.ObjectParameter); ok {
+			p.Value = typedValue(obj, params[i].Value)
+		}
+		switch v := p.Value.(type) {
+		case time.Time:
+			if t.Parameters[i].GetType() == "date" {
+				p.Value = civil.DateOf(v)
+			}
+		case time.Duration:
+			p.Value = typedValue(t.Parameters[i], v)
+		}
+		typed[i] = p
+	}
+	mapParams, err := getMapParams(typed, t.dialect)
 	if err != nil {
 		return nil, fmt.Errorf("fail to get map params: %w", err)
 	}
@@ -126,7 +292,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 
 	_, err = t.Client.ReadWriteTransaction(ctx, func(ctx context.Context, txn *spanner.ReadWriteTransaction) error {
 		stmt := spanner.Statement{
-			SQL:    t.Statement,
+			SQL:    statement,
 			Params: mapParams,
 		}
 		iter := txn.Query(ctx, stmt)
@@ -158,7 +324,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
-	return tools.ParseParams(t.Parameters, data, claims)
+	return tools.ParseParams(slices.Concat(t.Parameters, t.TemplateParameters), data, claims)
 }
 
 func (t Tool) Manifest() tools.Manifest {
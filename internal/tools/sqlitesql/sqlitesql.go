@@ -17,8 +17,11 @@ package sqlitesql
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
+	"slices"
 
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/tools"
@@ -26,6 +29,18 @@ import (
 
 const ToolKind string = "sqlite-sql"
 
+func init() {
+	tools.Register(ToolKind, newConfig)
+}
+
+func newConfig(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+	actual := Config{Name: name}
+	if err := decoder.DecodeContext(ctx, &actual); err != nil {
+		return nil, err
+	}
+	return actual, nil
+}
+
 type compatibleSource interface {
 	SQLiteDB() *sql.DB
 }
@@ -36,22 +51,34 @@ var _ compatibleSource = &sqlite.Source{}
 var compatibleSources = [...]string{sqlite.SourceKind}
 
 type Config struct {
-	Name         string           `yaml:"name" validate:"required"`
-	Kind         string           `yaml:"kind" validate:"required"`
-	Source       string           `yaml:"source" validate:"required"`
-	Description  string           `yaml:"description" validate:"required"`
-	Statement    string           `yaml:"statement" validate:"required"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name               string           `yaml:"name" validate:"required"`
+	Kind               string           `yaml:"kind" validate:"required"`
+	Source             string           `yaml:"source" validate:"required"`
+	Description        string           `yaml:"description" validate:"required"`
+	Statement          string           `yaml:"statement" validate:"required"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
+	Examples           []map[string]any `yaml:"examples"`
 }
 
 // validate interface
 var _ tools.ToolConfig = Config{}
+var _ tools.ReferencingToolConfig = Config{}
 
 func (cfg Config) ToolConfigKind() string {
 	return ToolKind
 }
 
+func (cfg Config) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{
+		Source:                cfg.Source,
+		CompatibleSourceKinds: compatibleSources[:],
+		AuthRequired:          cfg.AuthRequired,
+		Parameters:            slices.Concat(cfg.Parameters, cfg.TemplateParameters),
+	}
+}
+
 func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error) {
 	// verify source exists
 	rawS, ok := srcs[cfg.Source]
@@ -65,22 +92,34 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 		return nil, fmt.Errorf("invalid source for %q tool: source kind must be one of %q", ToolKind, compatibleSources)
 	}
 
+	if err := tools.CheckTemplateParameters(cfg.Statement, cfg.Parameters, cfg.TemplateParameters); err != nil {
+		return nil, err
+	}
+	allParameters := slices.Concat(cfg.Parameters, cfg.TemplateParameters)
+
+	if err := tools.CheckExamples(allParameters, cfg.Examples); err != nil {
+		return nil, err
+	}
+	inputSchema := allParameters.McpManifest()
+	inputSchema.Examples = cfg.Examples
+
 	mcpManifest := tools.McpManifest{
 		Name:        cfg.Name,
 		Description: cfg.Description,
-		InputSchema: cfg.Parameters.McpManifest(),
+		InputSchema: inputSchema,
 	}
 
 	// finish tool setup
 	t := Tool{
-		Name:         cfg.Name,
-		Kind:         ToolKind,
-		Parameters:   cfg.Parameters,
-		Statement:    cfg.Statement,
-		AuthRequired: cfg.AuthRequired,
-		Db:           s.SQLiteDB(),
-		manifest:     tools.Manifest{Description: cfg.Description, Parameters: cfg.Parameters.Manifest()},
-		mcpManifest:  mcpManifest,
+		Name:               cfg.Name,
+		Kind:               ToolKind,
+		Parameters:         cfg.Parameters,
+		TemplateParameters: cfg.TemplateParameters,
+		Statement:          cfg.Statement,
+		AuthRequired:       cfg.AuthRequired,
+		Db:                 s.SQLiteDB(),
+		manifest:           tools.Manifest{Description: cfg.Description, Parameters: allParameters.Manifest(), Examples: cfg.Examples},
+		mcpManifest:        mcpManifest,
 	}
 	return t, nil
 }
@@ -89,10 +128,11 @@ func (cfg Config) Initialize(srcs map[string]sources.Source) (tools.Tool, error)
 var _ tools.Tool = Tool{}
 
 type Tool struct {
-	Name         string           `yaml:"name"`
-	Kind         string           `yaml:"kind"`
-	AuthRequired []string         `yaml:"authRequired"`
-	Parameters   tools.Parameters `yaml:"parameters"`
+	Name               string           `yaml:"name"`
+	Kind               string           `yaml:"kind"`
+	AuthRequired       []string         `yaml:"authRequired"`
+	Parameters         tools.Parameters `yaml:"parameters"`
+	TemplateParameters tools.Parameters `yaml:"templateParameters"`
 
 	Db          *sql.DB
 	Statement   string `yaml:"statement"`
@@ -100,9 +140,33 @@ type Tool struct {
 	mcpManifest tools.McpManifest
 }
 
+// bindValues returns the values to bind to the statement. Objects are bound as
+// JSON text, which SQLite reads with its JSON functions.
+func bindValues(params tools.ParamValues) ([]any, error) {
+	values := params.AsSlice()
+	for i, v := range values {
+		if obj, ok := v.(map[string]any); ok {
+			b, err := json.Marshal(obj)
+			if err != nil {
+				return nil, fmt.Errorf("unable to encode parameter %q: %w", params[i].Name, err)
+			}
+			values[i] = string(b)
+		}
+	}
+	return values, nil
+}
+
 func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, error) {
+	statement, params, err := tools.ResolveTemplateParams(t.Statement, t.TemplateParameters, params, tools.DoubleQuotes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve statement: %w", err)
+	}
+	sliceParams, err := bindValues(params)
+	if err != nil {
+		return nil, err
+	}
 	// Execute the SQL query with parameters
-	rows, err := t.Db.QueryContext(ctx, t.Statement, params.AsSlice()...)
+	rows, err := t.Db.QueryContext(ctx, statement, sliceParams...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to execute query: %w", err)
 	}
@@ -156,7 +220,7 @@ func (t Tool) Invoke(ctx context.Context, params tools.ParamValues) ([]any, erro
 }
 
 func (t Tool) ParseParams(data map[string]any, claims map[string]map[string]any) (tools.ParamValues, error) {
-	return tools.ParseParams(t.Parameters, data, claims)
+	return tools.ParseParams(slices.Concat(t.Parameters, t.TemplateParameters), data, claims)
 }
 
 func (t Tool) Manifest() tools.Manifest {
@@ -15,14 +15,19 @@
 package sqlitesql_test
 
 import (
+	"path/filepath"
+	"strings"
 	"testing"
 
 	yaml "github.com/goccy/go-yaml"
 	"github.com/google/go-cmp/cmp"
 	"github.com/googleapis/genai-toolbox/internal/server"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/sources/sqlite"
 	"github.com/googleapis/genai-toolbox/internal/testutils"
 	"github.com/googleapis/genai-toolbox/internal/tools"
 	"github.com/googleapis/genai-toolbox/internal/tools/sqlitesql"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 func TestParseFromYamlSQLite(t *testing.T) {
@@ -92,3 +97,116 @@ func TestParseFromYamlSQLite(t *testing.T) {
 	}
 
 }
+
+func TestInvokeWithTemplateParameters(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := sqlite.Config{Name: "my-sqlite", Kind: sqlite.SourceKind, Database: filepath.Join(t.TempDir(), "test.db")}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+	db := src.(*sqlite.Source).SQLiteDB()
+	for _, stmt := range []string{
+		`CREATE TABLE flights (id INTEGER, airline TEXT)`,
+		`CREATE TABLE hotels (id INTEGER, name TEXT)`,
+		`INSERT INTO flights VALUES (1, 'CY'), (2, 'DL')`,
+		`INSERT INTO hotels VALUES (1, 'Hilton')`,
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("unable to set up database: %s", err)
+		}
+	}
+
+	in := `
+	kind: sqlite-sql
+	source: my-sqlite
+	description: Get a row of a table.
+	statement: SELECT * FROM {{.table}} WHERE id = ?
+	parameters:
+		- name: id
+		  type: integer
+		  description: The id of the row.
+	templateParameters:
+		- name: table
+		  type: string
+		  description: The table to read.
+		  allowedValues: [flights, hotels]
+	`
+	cfg := sqlitesql.Config{Name: "get_row"}
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &cfg); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-sqlite": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	if got := tool.McpManifest().InputSchema.Required; !cmp.Equal(got, []string{"id", "table"}) {
+		t.Fatalf("incorrect required parameters: %v", got)
+	}
+
+	params, err := tool.ParseParams(map[string]any{"id": 1, "table": "hotels"}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	want := []any{map[string]any{"id": int64(1), "name": "Hilton"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+
+	_, err = tool.ParseParams(map[string]any{"id": 1, "table": "users"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "is not one of the allowed values") {
+		t.Fatalf("expected the table to be rejected, got %v", err)
+	}
+}
+
+func TestInvokeWithObjectParameter(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	src, err := sqlite.Config{Name: "my-sqlite", Kind: sqlite.SourceKind, Database: filepath.Join(t.TempDir(), "test.db")}.Initialize(ctx, noop.NewTracerProvider().Tracer(""))
+	if err != nil {
+		t.Fatalf("unable to initialize source: %s", err)
+	}
+
+	in := `
+	kind: sqlite-sql
+	source: my-sqlite
+	description: Get the city of an address.
+	statement: SELECT json_extract(?, '$.city') AS city
+	parameters:
+		- name: address
+		  type: object
+		  description: The address.
+		  properties:
+			- name: city
+			  type: string
+			  description: The city.
+	`
+	cfg := sqlitesql.Config{Name: "get_city"}
+	if err := yaml.UnmarshalContext(ctx, testutils.FormatYaml(in), &cfg); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	tool, err := cfg.Initialize(map[string]sources.Source{"my-sqlite": src})
+	if err != nil {
+		t.Fatalf("unable to initialize tool: %s", err)
+	}
+	params, err := tool.ParseParams(map[string]any{"address": map[string]any{"city": "Basel"}}, nil)
+	if err != nil {
+		t.Fatalf("unable to parse params: %s", err)
+	}
+	got, err := tool.Invoke(ctx, params)
+	if err != nil {
+		t.Fatalf("unable to invoke: %s", err)
+	}
+	want := []any{map[string]any{"city": "Basel"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect result: diff %v", diff)
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+// IdentifierQuotes are the characters a SQL dialect quotes identifiers with.
+type IdentifierQuotes struct {
+	Open, Close string
+	// Escape is the character that escapes others in quoted identifiers, if
+	// any.
+	Escape string
+}
+
+var (
+	// DoubleQuotes quote identifiers of Postgres, SQLite and the PostgreSQL
+	// dialect of Spanner.
+	DoubleQuotes = IdentifierQuotes{`"`, `"`, ""}
+	// Backticks quote identifiers of MySQL and the GoogleSQL dialect of
+	// Spanner, which escapes characters of quoted identifiers with a backslash.
+	Backticks = IdentifierQuotes{"`", "`", `\`}
+	// Brackets quote identifiers of SQL Server.
+	Brackets = IdentifierQuotes{"[", "]", ""}
+)
+
+// Quote quotes the identifier "s". Identifiers containing quote or escape
+// characters are rejected rather than escaped, since dialects escape them
+// differently.
+func (q IdentifierQuotes) Quote(s string) (string, error) {
+	if s == "" || strings.ContainsAny(s, q.Open+q.Close+q.Escape+"\x00") {
+		return "", fmt.Errorf("%q is not a valid identifier", s)
+	}
+	return q.Open + s + q.Close, nil
+}
+
+// CheckTemplateParameters checks the template parameters of a SQL tool, which
+// are substituted into its statement as identifiers, such as table and column
+// names. Each must be a string, or an array of strings, restricted with
+// allowedValues or a pattern, and the statement must be a valid template.
+// Unlike the pattern of other parameters, which may match any part of a
+// value, the pattern of a template parameter must match the whole value.
+func CheckTemplateParameters(statement string, params, templateParams Parameters) error {
+	if len(templateParams) == 0 {
+		return nil
+	}
+	for _, p := range templateParams {
+		name := p.GetName()
+		for _, other := range params {
+			if other.GetName() == name {
+				return fmt.Errorf("template parameter %q has the same name as a parameter", name)
+			}
+		}
+		if p.IsNullable() {
+			return fmt.Errorf("template parameter %q cannot be nullable", name)
+		}
+		if !p.IsRequired() && p.GetDefault() == nil {
+			return fmt.Errorf("template parameter %q must be required or have a default", name)
+		}
+		s := p
+		if a, ok := p.(*ArrayParameter); ok {
+			s = a.Items
+		}
+		str, ok := s.(*StringParameter)
+		if !ok {
+			return fmt.Errorf("template parameter %q must be a string or an array of strings", name)
+		}
+		if len(str.AllowedValues) == 0 && str.Pattern == "" {
+			return fmt.Errorf("template parameter %q must restrict its values with 'allowedValues' or 'pattern'", name)
+		}
+	}
+	if _, err := newStatementTemplate(statement); err != nil {
+		return fmt.Errorf("invalid statement template: %w", err)
+	}
+	return nil
+}
+
+// ResolveTemplateParams substitutes the values of template parameters into a
+// statement, quoting each as an identifier. Arrays are substituted as a comma
+// separated list of identifiers. The values of template parameters follow
+// those of the other parameters in "params", which are returned to be bound
+// to the resolved statement.
+func ResolveTemplateParams(statement string, templateParams Parameters, params ParamValues, quotes IdentifierQuotes) (string, ParamValues, error) {
+	if len(templateParams) == 0 {
+		return statement, params, nil
+	}
+	n := len(params) - len(templateParams)
+	if n < 0 {
+		return "", nil, fmt.Errorf("expected values for %d template parameters, got %d", len(templateParams), len(params))
+	}
+	statement, err := resolveTemplate(statement, templateParams, params[n:], quotes)
+	if err != nil {
+		return "", nil, err
+	}
+	return statement, params[:n], nil
+}
+
+func resolveTemplate(statement string, templateParams Parameters, values ParamValues, quotes IdentifierQuotes) (string, error) {
+	data := make(map[string]string, len(values))
+	for i, v := range values {
+		var names []string
+		switch v := v.Value.(type) {
+		case string:
+			names = []string{v}
+		case []any:
+			for _, item := range v {
+				s, _ := item.(string)
+				names = append(names, s)
+			}
+		}
+		if len(names) == 0 {
+			return "", fmt.Errorf("template parameter %q has no value", v.Name)
+		}
+		pattern, err := identifierPattern(templateParams[i])
+		if err != nil {
+			return "", fmt.Errorf("invalid pattern for template parameter %q: %w", v.Name, err)
+		}
+		quoted := make([]string, len(names))
+		for j, name := range names {
+			if pattern != nil && !pattern.MatchString(name) {
+				return "", fmt.Errorf("invalid value for template parameter %q: %q does not match the whole pattern %q", v.Name, name, pattern.String())
+			}
+			if quoted[j], err = quotes.Quote(name); err != nil {
+				return "", fmt.Errorf("invalid value for template parameter %q: %w", v.Name, err)
+			}
+		}
+		data[v.Name] = strings.Join(quoted, ", ")
+	}
+	tmpl, err := newStatementTemplate(statement)
+	if err != nil {
+		return "", fmt.Errorf("invalid statement template: %w", err)
+	}
+	var b bytes.Buffer
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("unable to substitute template parameters: %w", err)
+	}
+	return b.String(), nil
+}
+
+// identifierPattern returns the pattern of a template parameter, anchored so
+// that it matches whole identifiers, or nil if it has none.
+func identifierPattern(p Parameter) (*regexp.Regexp, error) {
+	if a, ok := p.(*ArrayParameter); ok {
+		p = a.Items
+	}
+	str, ok := p.(*StringParameter)
+	if !ok || str.Pattern == "" {
+		return nil, nil
+	}
+	return regexp.Compile("^(?:" + str.Pattern + ")$")
+}
+
+func newStatementTemplate(statement string) (*template.Template, error) {
+	return template.New("statement").Option("missingkey=error").Parse(statement)
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func tableParameter() *tools.StringParameter {
+	p := tools.NewStringParameter("table", "The table to query.")
+	p.AllowedValues = []string{"flights", "hotels"}
+	return p
+}
+
+func TestCheckTemplateParameters(t *testing.T) {
+	columns := tools.NewArrayParameter("columns", "The columns to return.", tools.NewStringParameter("column", "A column."))
+	columns.Items.(*tools.StringParameter).Pattern = "^[a-z_]+$"
+	optional := tableParameter()
+	optional.Required = new(bool)
+
+	tcs := []struct {
+		desc           string
+		statement      string
+		params         tools.Parameters
+		templateParams tools.Parameters
+		err            string
+	}{
+		{
+			desc:           "valid",
+			statement:      "SELECT {{.columns}} FROM {{.table}} WHERE id = $1",
+			params:         tools.Parameters{tools.NewIntParameter("id", "The id.")},
+			templateParams: tools.Parameters{tableParameter(), columns},
+		},
+		{
+			desc:           "name clash",
+			statement:      "SELECT * FROM {{.table}}",
+			params:         tools.Parameters{tools.NewStringParameter("table", "The table.")},
+			templateParams: tools.Parameters{tableParameter()},
+			err:            `template parameter "table" has the same name as a parameter`,
+		},
+		{
+			desc:           "unrestricted",
+			statement:      "SELECT * FROM {{.table}}",
+			templateParams: tools.Parameters{tools.NewStringParameter("table", "The table.")},
+			err:            `template parameter "table" must restrict its values with 'allowedValues' or 'pattern'`,
+		},
+		{
+			desc:           "not a string",
+			statement:      "SELECT * FROM t LIMIT {{.limit}}",
+			templateParams: tools.Parameters{tools.NewIntParameter("limit", "The limit.")},
+			err:            `template parameter "limit" must be a string or an array of strings`,
+		},
+		{
+			desc:           "optional without default",
+			statement:      "SELECT * FROM {{.table}}",
+			templateParams: tools.Parameters{optional},
+			err:            `template parameter "table" must be required or have a default`,
+		},
+		{
+			desc:           "invalid template",
+			statement:      "SELECT * FROM {{.table",
+			templateParams: tools.Parameters{tableParameter()},
+			err:            "invalid statement template",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			err := tools.CheckTemplateParameters(tc.statement, tc.params, tc.templateParams)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestResolveTemplateParams(t *testing.T) {
+	columns := tools.NewArrayParameter("columns", "The columns to return.", tools.NewStringParameter("column", "A column."))
+	templateParams := tools.Parameters{tableParameter(), columns}
+	statement := "SELECT {{.columns}} FROM {{.table}} WHERE id = $1"
+	params := tools.ParamValues{
+		{Name: "id", Value: 1},
+		{Name: "table", Value: "flights"},
+		{Name: "columns", Value: []any{"id", "airline"}},
+	}
+
+	tcs := []struct {
+		desc   string
+		quotes tools.IdentifierQuotes
+		want   string
+	}{
+		{desc: "double quotes", quotes: tools.DoubleQuotes, want: `SELECT "id", "airline" FROM "flights" WHERE id = $1`},
+		{desc: "backticks", quotes: tools.Backticks, want: "SELECT `id`, `airline` FROM `flights` WHERE id = $1"},
+		{desc: "brackets", quotes: tools.Brackets, want: "SELECT [id], [airline] FROM [flights] WHERE id = $1"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			got, rest, err := tools.ResolveTemplateParams(statement, templateParams, params, tc.quotes)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != tc.want {
+				t.Fatalf("incorrect statement: got %q, want %q", got, tc.want)
+			}
+			if diff := cmp.Diff(params[:1], rest); diff != "" {
+				t.Fatalf("incorrect remaining params: diff %v", diff)
+			}
+		})
+	}
+}
+
+func TestFailResolveTemplateParams(t *testing.T) {
+	templateParams := tools.Parameters{tableParameter()}
+	tcs := []struct {
+		desc      string
+		statement string
+		value     any
+		quotes    tools.IdentifierQuotes
+		err       string
+	}{
+		{
+			desc:      "embedded quote",
+			statement: "SELECT * FROM {{.table}}",
+			value:     `flights" --`,
+			quotes:    tools.DoubleQuotes,
+			err:       `invalid value for template parameter "table": "flights\" --" is not a valid identifier`,
+		},
+		{
+			desc:      "closing bracket",
+			statement: "SELECT * FROM {{.table}}",
+			value:     "flights]; DROP TABLE users; --",
+			quotes:    tools.Brackets,
+			err:       `invalid value for template parameter "table"`,
+		},
+		{
+			desc:      "escape character",
+			statement: "SELECT * FROM {{.table}}",
+			value:     `flights\`,
+			quotes:    tools.Backticks,
+			err:       `invalid value for template parameter "table": "flights\\" is not a valid identifier`,
+		},
+		{
+			desc:      "empty",
+			statement: "SELECT * FROM {{.table}}",
+			value:     "",
+			quotes:    tools.Backticks,
+			err:       `"" is not a valid identifier`,
+		},
+		{
+			desc:      "empty array",
+			statement: "SELECT * FROM {{.table}}",
+			value:     []any{},
+			quotes:    tools.Backticks,
+			err:       `template parameter "table" has no value`,
+		},
+		{
+			desc:      "unknown key",
+			statement: "SELECT * FROM {{.tables}}",
+			value:     "flights",
+			quotes:    tools.Backticks,
+			err:       "unable to substitute template parameters",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			params := tools.ParamValues{{Name: "table", Value: tc.value}}
+			_, _, err := tools.ResolveTemplateParams(tc.statement, templateParams, params, tc.quotes)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestResolveTemplateParamsPattern(t *testing.T) {
+	column := tools.NewStringParameter("column", "The column to return.")
+	column.Pattern = "[a-z_]+"
+	templateParams := tools.Parameters{column}
+	statement := "SELECT {{.column}} FROM flights"
+
+	got, _, err := tools.ResolveTemplateParams(statement, templateParams, tools.ParamValues{{Name: "column", Value: "airline"}}, tools.DoubleQuotes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if want := `SELECT "airline" FROM flights`; got != want {
+		t.Fatalf("incorrect statement: got %q, want %q", got, want)
+	}
+
+	// the pattern must match the whole value, not only a part of it
+	_, _, err = tools.ResolveTemplateParams(statement, templateParams, tools.ParamValues{{Name: "column", Value: "airline, password"}}, tools.DoubleQuotes)
+	want := `invalid value for template parameter "column": "airline, password" does not match the whole pattern "^(?:[a-z_]+)$"`
+	if err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
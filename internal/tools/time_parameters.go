@@ -0,0 +1,286 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	typeDate      = "date"
+	typeTimestamp = "timestamp"
+	typeDuration  = "duration"
+)
+
+// FormatValue returns the parsed value "v" of the parameter "p" as text in
+// the format it is accepted in: a date, an RFC 3339 timestamp or an ISO 8601
+// duration. Values of other types are returned as is.
+func FormatValue(p Parameter, v any) any {
+	switch v := v.(type) {
+	case time.Time:
+		if p.GetType() == typeDate {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339Nano)
+	case time.Duration:
+		return isoDuration(v)
+	}
+	return v
+}
+
+// NewDateParameter is a convenience function for initializing a DateParameter.
+func NewDateParameter(name, desc string) *DateParameter {
+	return &DateParameter{
+		CommonParameter: CommonParameter{
+			Name: name,
+			Type: typeDate,
+			Desc: desc,
+		},
+	}
+}
+
+var _ Parameter = &DateParameter{}
+
+// DateParameter is a parameter representing the "date" type. Values are parsed
+// as a time.Time at midnight UTC.
+type DateParameter struct {
+	CommonParameter `yaml:",inline"`
+	// Layouts are Go time layouts accepted besides "2006-01-02".
+	Layouts []string `yaml:"layouts"`
+}
+
+// Parse parses the value "v" as a date.
+func (p *DateParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	layouts := append([]string{time.DateOnly}, p.Layouts...)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			y, m, d := t.Date()
+			return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+		}
+	}
+	return nil, fmt.Errorf("%q is not a date in any of the layouts %q", s, layouts)
+}
+
+func (p *DateParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+// Manifest returns the manifest for the DateParameter.
+func (p *DateParameter) Manifest() ParameterManifest {
+	m := p.CommonParameter.Manifest()
+	m.Default = FormatValue(p, p.Default)
+	return m
+}
+
+// McpManifest returns the MCP manifest for the DateParameter.
+func (p *DateParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Type, m.Format = "string", "date"
+	m.Default = FormatValue(p, p.Default)
+	return m
+}
+
+// NewTimestampParameter is a convenience function for initializing a TimestampParameter.
+func NewTimestampParameter(name, desc string) *TimestampParameter {
+	return &TimestampParameter{
+		CommonParameter: CommonParameter{
+			Name: name,
+			Type: typeTimestamp,
+			Desc: desc,
+		},
+	}
+}
+
+var _ Parameter = &TimestampParameter{}
+
+// TimestampParameter is a parameter representing the "timestamp" type. Values
+// are parsed as a time.Time in UTC.
+type TimestampParameter struct {
+	CommonParameter `yaml:",inline"`
+	// Layouts are Go time layouts accepted besides RFC 3339.
+	Layouts []string `yaml:"layouts"`
+	// Timezone is the IANA name of the location of timestamps whose layout
+	// has no UTC offset. Defaults to UTC.
+	Timezone string `yaml:"timezone"`
+}
+
+// Parse parses the value "v" as a timestamp.
+func (p *TimestampParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	loc, err := p.location()
+	if err != nil {
+		return nil, err
+	}
+	layouts := append([]string{time.RFC3339Nano}, p.Layouts...)
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return nil, fmt.Errorf("%q is not a timestamp in any of the layouts %q", s, layouts)
+}
+
+func (p *TimestampParameter) location() (*time.Location, error) {
+	if p.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q", p.Timezone)
+	}
+	return loc, nil
+}
+
+func (p *TimestampParameter) checkConstraints() error {
+	_, err := p.location()
+	return err
+}
+
+func (p *TimestampParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+// Manifest returns the manifest for the TimestampParameter.
+func (p *TimestampParameter) Manifest() ParameterManifest {
+	m := p.CommonParameter.Manifest()
+	m.Default = FormatValue(p, p.Default)
+	return m
+}
+
+// McpManifest returns the MCP manifest for the TimestampParameter.
+func (p *TimestampParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Type, m.Format = "string", "date-time"
+	m.Default = FormatValue(p, p.Default)
+	return m
+}
+
+// NewDurationParameter is a convenience function for initializing a DurationParameter.
+func NewDurationParameter(name, desc string) *DurationParameter {
+	return &DurationParameter{
+		CommonParameter: CommonParameter{
+			Name: name,
+			Type: typeDuration,
+			Desc: desc,
+		},
+	}
+}
+
+var _ Parameter = &DurationParameter{}
+
+// DurationParameter is a parameter representing the "duration" type. Values
+// are parsed as a time.Duration.
+type DurationParameter struct {
+	CommonParameter `yaml:",inline"`
+}
+
+// Parse parses the value "v" as an ISO 8601 duration, such as "PT1H30M", or
+// a Go duration, such as "1h30m".
+func (p *DurationParameter) Parse(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, &ParseTypeError{p.Name, p.Type, v}
+	}
+	if d, ok := parseISODuration(s); ok {
+		return d, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+	return nil, fmt.Errorf("%q is not an ISO 8601 duration such as \"PT1H30M\", years and months are not supported", s)
+}
+
+func (p *DurationParameter) GetAuthServices() []ParamAuthService {
+	return p.AuthServices
+}
+
+// Manifest returns the manifest for the DurationParameter.
+func (p *DurationParameter) Manifest() ParameterManifest {
+	m := p.CommonParameter.Manifest()
+	m.Default = FormatValue(p, p.Default)
+	return m
+}
+
+// McpManifest returns the MCP manifest for the DurationParameter.
+func (p *DurationParameter) McpManifest() ParameterMcpManifest {
+	m := p.CommonParameter.McpManifest()
+	m.Type, m.Format = "string", "duration"
+	m.Default = FormatValue(p, p.Default)
+	return m
+}
+
+// isoDurationPattern matches the ISO 8601 durations of a fixed length, those
+// without years or months.
+var isoDurationPattern = regexp.MustCompile(`^([-+]?)P(?:([\d.,]+)W)?(?:([\d.,]+)D)?(?:T(?:([\d.,]+)H)?(?:([\d.,]+)M)?(?:([\d.,]+)S)?)?$`)
+
+func parseISODuration(s string) (time.Duration, bool) {
+	m := isoDurationPattern.FindStringSubmatch(s)
+	if m == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return 0, false
+	}
+	units := []time.Duration{7 * 24 * time.Hour, 24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, unit := range units {
+		if m[i+2] == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.Replace(m[i+2], ",", ".", 1), 64)
+		if err != nil {
+			return 0, false
+		}
+		d += time.Duration(n * float64(unit))
+	}
+	if m[1] == "-" {
+		d = -d
+	}
+	return d, true
+}
+
+// isoDuration formats "d" as an ISO 8601 duration in hours, minutes and
+// seconds, such as "PT1H30M".
+func isoDuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	if d < 0 {
+		b.WriteString("-")
+		d = -d
+	}
+	b.WriteString("PT")
+	if h := d / time.Hour; h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+		d -= h * time.Hour
+	}
+	if m := d / time.Minute; m > 0 {
+		fmt.Fprintf(&b, "%dM", m)
+		d -= m * time.Minute
+	}
+	if d > 0 {
+		fmt.Fprintf(&b, "%sS", strconv.FormatFloat(d.Seconds(), 'f', -1, 64))
+	}
+	return b.String()
+}
@@ -0,0 +1,148 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/testutils"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+func TestTimeParametersParse(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: day
+  type: date
+  description: The day.
+  layouts: [02/01/2006]
+- name: at
+  type: timestamp
+  description: The time.
+  layouts: ["2006-01-02 15:04"]
+  timezone: Europe/Zurich
+- name: within
+  type: duration
+  description: The window.
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+
+	tcs := []struct {
+		name  string
+		param int
+		in    string
+		want  any
+	}{
+		{name: "date", param: 0, in: "2025-03-30", want: time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC)},
+		{name: "date in layout", param: 0, in: "30/03/2025", want: time.Date(2025, 3, 30, 0, 0, 0, 0, time.UTC)},
+		{name: "timestamp", param: 1, in: "2025-03-30T10:00:00+02:00", want: time.Date(2025, 3, 30, 8, 0, 0, 0, time.UTC)},
+		{name: "timestamp in layout uses timezone", param: 1, in: "2025-03-30 10:00", want: time.Date(2025, 3, 30, 8, 0, 0, 0, time.UTC)},
+		{name: "timestamp in layout before DST", param: 1, in: "2025-03-29 10:00", want: time.Date(2025, 3, 29, 9, 0, 0, 0, time.UTC)},
+		{name: "ISO 8601 duration", param: 2, in: "P1DT1H30M0.5S", want: 25*time.Hour + 30*time.Minute + 500*time.Millisecond},
+		{name: "negative ISO 8601 duration", param: 2, in: "-PT15M", want: -15 * time.Minute},
+		{name: "Go duration", param: 2, in: "1h30m", want: 90 * time.Minute},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := params[tc.param].Parse(tc.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect value (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	failures := []struct {
+		name  string
+		param int
+		in    any
+		err   string
+	}{
+		{name: "date with time", param: 0, in: "2025-03-30T10:00:00Z", err: `"2025-03-30T10:00:00Z" is not a date in any of the layouts ["2006-01-02" "02/01/2006"]`},
+		{name: "timestamp without offset", param: 1, in: "2025-03-30T10:00:00", err: `"2025-03-30T10:00:00" is not a timestamp in any of the layouts`},
+		{name: "timestamp not a string", param: 1, in: 5, err: `not type "timestamp"`},
+		{name: "duration in months", param: 2, in: "P1M", err: `"P1M" is not an ISO 8601 duration`},
+		{name: "empty duration", param: 2, in: "PT", err: `"PT" is not an ISO 8601 duration`},
+	}
+	for _, tc := range failures {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := params[tc.param].Parse(tc.in)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %v, want substring %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestTimeParametersManifest(t *testing.T) {
+	ctx, err := testutils.ContextWithNewLogger()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	in := `
+- name: day
+  type: date
+  description: The day.
+  default: "2025-01-01"
+- name: at
+  type: timestamp
+  description: The time.
+- name: within
+  type: duration
+  description: The window.
+  default: 90m
+`
+	var params tools.Parameters
+	if err := yaml.UnmarshalContext(ctx, []byte(in), &params); err != nil {
+		t.Fatalf("unable to unmarshal: %s", err)
+	}
+	if got := params[2].GetDefault(); got != 90*time.Minute {
+		t.Fatalf("incorrect default: %v", got)
+	}
+	want := map[string]tools.ParameterMcpManifest{
+		"day":    {Type: "string", Format: "date", Description: "The day.", Default: "2025-01-01"},
+		"at":     {Type: "string", Format: "date-time", Description: "The time."},
+		"within": {Type: "string", Format: "duration", Description: "The window.", Default: "PT1H30M"},
+	}
+	if diff := cmp.Diff(want, params.McpManifest().Properties); diff != "" {
+		t.Fatalf("incorrect MCP manifest (-want +got):\n%s", diff)
+	}
+	if m := params[0].Manifest(); m.Type != "date" || m.Default != "2025-01-01" {
+		t.Fatalf("incorrect manifest: %+v", m)
+	}
+
+	ts := tools.NewTimestampParameter("at", "The time.")
+	if got := tools.FormatValue(ts, time.Date(2025, 3, 30, 8, 0, 0, 5, time.UTC)); got != "2025-03-30T08:00:00.000000005Z" {
+		t.Fatalf("incorrect formatted timestamp: %v", got)
+	}
+
+	bad := "- {name: at, type: timestamp, description: The time., timezone: Mars/Olympus}"
+	err = yaml.UnmarshalContext(ctx, []byte(bad), &params)
+	if want := `invalid constraints for parameter "at": unknown timezone "Mars/Olympus"`; err == nil || !strings.Contains(err.Error(), want) {
+		t.Fatalf("unexpected error: got %v, want substring %q", err, want)
+	}
+}
@@ -16,8 +16,12 @@ package tools
 
 import (
 	"context"
+	"fmt"
+	"maps"
 	"slices"
+	"strings"
 
+	yaml "github.com/goccy/go-yaml"
 	"github.com/googleapis/genai-toolbox/internal/sources"
 )
 
@@ -26,6 +30,23 @@ type ToolConfig interface {
 	Initialize(map[string]sources.Source) (Tool, error)
 }
 
+// ConfigReferences lists the resources a ToolConfig refers to by name.
+type ConfigReferences struct {
+	Source                string
+	CompatibleSourceKinds []string
+	AuthRequired          []string
+	Parameters            Parameters
+	// AuthServices are the auth services the tool reads claims from, other
+	// than those of its parameters.
+	AuthServices []string
+}
+
+// ReferencingToolConfig is implemented by ToolConfigs so that references to
+// other resources can be checked without initializing any sources.
+type ReferencingToolConfig interface {
+	ToolConfigReferences() ConfigReferences
+}
+
 type Tool interface {
 	Invoke(context.Context, ParamValues) ([]any, error)
 	ParseParams(map[string]any, map[string]map[string]any) (ParamValues, error)
@@ -38,6 +59,9 @@ type Tool interface {
 type Manifest struct {
 	Description string              `json:"description"`
 	Parameters  []ParameterManifest `json:"parameters"`
+	// Examples are example invocations of the tool, which map the names of
+	// its parameters to values.
+	Examples []map[string]any `json:"examples,omitempty"`
 }
 
 // Definition for a tool the MCP client can call.
@@ -63,3 +87,43 @@ func IsAuthorized(authRequiredSources []string, verifiedAuthServices []string) b
 	}
 	return false
 }
+
+// ToolConfigFactory decodes the configuration of the tool with the given name.
+type ToolConfigFactory func(ctx context.Context, name string, decoder *yaml.Decoder) (ToolConfig, error)
+
+var toolRegistry = make(map[string]ToolConfigFactory)
+
+// Register makes a kind of tool available in tools files. It is intended to
+// be called from the init function of the package implementing the kind, and
+// panics if the kind is already registered.
+func Register(kind string, factory ToolConfigFactory) {
+	if _, exists := toolRegistry[kind]; exists {
+		panic(fmt.Sprintf("tools: kind %q is already registered", kind))
+	}
+	toolRegistry[kind] = factory
+}
+
+// Kinds returns the registered kinds of tools in sorted order.
+func Kinds() []string {
+	return slices.Sorted(maps.Keys(toolRegistry))
+}
+
+// DecodeConfig decodes the configuration of a tool with the factory
+// registered for its kind.
+func DecodeConfig(ctx context.Context, kind, name string, decoder *yaml.Decoder) (ToolConfig, error) {
+	factory, ok := toolRegistry[kind]
+	if !ok {
+		return nil, fmt.Errorf("%q is not a valid kind of tool, must be one of: %s", kind, strings.Join(Kinds(), ", "))
+	}
+	cfg, err := factory(ctx, name, decoder)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse as %q: %w", kind, err)
+	}
+	return cfg, nil
+}
+
+// DefaultConfig returns the configuration of a tool of the given kind with
+// only its defaults set, so that its fields can be inspected.
+func DefaultConfig(kind string) (ToolConfig, error) {
+	return DecodeConfig(context.Background(), kind, "", yaml.NewDecoder(strings.NewReader("{}")))
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	yaml "github.com/goccy/go-yaml"
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+	"github.com/googleapis/genai-toolbox/internal/util"
+)
+
+const testToolKind = "registry-test"
+
+type testToolConfig struct {
+	Name      string `yaml:"name"`
+	Kind      string `yaml:"kind"`
+	Statement string `yaml:"statement"`
+}
+
+func (testToolConfig) ToolConfigKind() string {
+	return testToolKind
+}
+
+func (testToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return nil, nil
+}
+
+func init() {
+	tools.Register(testToolKind, func(ctx context.Context, name string, decoder *yaml.Decoder) (tools.ToolConfig, error) {
+		actual := testToolConfig{Name: name}
+		if err := decoder.DecodeContext(ctx, &actual); err != nil {
+			return nil, err
+		}
+		return actual, nil
+	})
+}
+
+func TestDecodeConfig(t *testing.T) {
+	dec, err := util.NewStrictDecoder(map[string]any{"kind": testToolKind, "statement": "SELECT 1;"})
+	if err != nil {
+		t.Fatalf("unable to create decoder: %s", err)
+	}
+	got, err := tools.DecodeConfig(context.Background(), testToolKind, "my-tool", dec)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := testToolConfig{Name: "my-tool", Kind: testToolKind, Statement: "SELECT 1;"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect config (-want +got):\n%s", diff)
+	}
+	if !slices.Contains(tools.Kinds(), testToolKind) {
+		t.Fatalf("registered kind missing from %v", tools.Kinds())
+	}
+}
+
+func TestFailDecodeConfig(t *testing.T) {
+	tcs := []struct {
+		desc string
+		kind string
+		in   map[string]any
+		err  string
+	}{
+		{
+			desc: "unknown kind",
+			kind: "not-a-kind",
+			in:   map[string]any{"kind": "not-a-kind"},
+			err:  `"not-a-kind" is not a valid kind of tool, must be one of: `,
+		},
+		{
+			desc: "unknown field",
+			kind: testToolKind,
+			in:   map[string]any{"kind": testToolKind, "foo": "bar"},
+			err:  `unable to parse as "registry-test"`,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			dec, err := util.NewStrictDecoder(tc.in)
+			if err != nil {
+				t.Fatalf("unable to create decoder: %s", err)
+			}
+			_, err = tools.DecodeConfig(context.Background(), tc.kind, "my-tool", dec)
+			if err == nil {
+				t.Fatalf("expect decoding to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want substring %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestRegisterDuplicateKind(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expect registering a duplicate kind to panic")
+		}
+	}()
+	tools.Register(testToolKind, nil)
+}
@@ -15,12 +15,216 @@
 package tools
 
 import (
+	"errors"
 	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
 )
 
 type ToolsetConfig struct {
 	Name      string   `yaml:"name"`
 	ToolNames []string `yaml:",inline"`
+	// Selectors choose tools by pattern, kind or source, or from other
+	// toolsets. They are resolved into ToolNames by ResolveToolsets.
+	Selectors []ToolSelector `yaml:"-"`
+}
+
+// ToolSelector is an entry of a toolset. Exactly one of its fields other than
+// Exclude is set.
+type ToolSelector struct {
+	// Tool is the name of a tool, or a glob pattern matching tool names.
+	Tool string
+	// Toolset includes the tools of another toolset.
+	Toolset string
+	// Regex is a regular expression matching whole tool names.
+	Regex string
+	// Kind selects the tools of a kind.
+	Kind string
+	// Source selects the tools that execute against a source.
+	Source string
+	// Exclude removes the selected tools from the toolset instead.
+	Exclude bool
+}
+
+// ErrToolsetCycle is returned when toolsets include each other in a cycle.
+var ErrToolsetCycle = errors.New("toolsets include each other in a cycle")
+
+// isGlob reports whether s is a glob pattern rather than a tool name.
+func isGlob(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
+
+// compileRegex compiles the regex of a selector, anchored so that like a glob
+// pattern it matches whole tool names rather than any part of them.
+func compileRegex(s string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + s + ")$")
+}
+
+// ParseToolSelector parses an entry of a toolset, which is either a tool name
+// or glob pattern, or a mapping with a single "tool", "toolset", "regex",
+// "kind", "source" or "exclude" key. "exclude" takes any other entry.
+func ParseToolSelector(v any) (ToolSelector, error) {
+	return parseToolSelector(v, true)
+}
+
+func parseToolSelector(v any, allowExclude bool) (ToolSelector, error) {
+	var sel ToolSelector
+	if s, ok := v.(string); ok {
+		v = map[string]any{"tool": s}
+	}
+	m, ok := v.(map[string]any)
+	if !ok || len(m) != 1 {
+		return sel, fmt.Errorf("toolset entry must be a tool name or a mapping with a single key, got %v", v)
+	}
+	for key, raw := range m {
+		if key == "exclude" && allowExclude {
+			sel, err := parseToolSelector(raw, false)
+			if err != nil {
+				return sel, fmt.Errorf("invalid exclude: %w", err)
+			}
+			sel.Exclude = true
+			return sel, nil
+		}
+		value, ok := raw.(string)
+		if !ok || value == "" {
+			return sel, fmt.Errorf("toolset entry %q must be a non-empty string", key)
+		}
+		switch key {
+		case "tool":
+			if _, err := path.Match(value, ""); err != nil {
+				return sel, fmt.Errorf("invalid glob pattern %q: %w", value, err)
+			}
+			sel.Tool = value
+		case "toolset":
+			sel.Toolset = value
+		case "regex":
+			if _, err := compileRegex(value); err != nil {
+				return sel, fmt.Errorf("invalid regex %q: %w", value, err)
+			}
+			sel.Regex = value
+		case "kind":
+			sel.Kind = value
+		case "source":
+			sel.Source = value
+		default:
+			return sel, fmt.Errorf("unknown toolset entry %q", key)
+		}
+	}
+	return sel, nil
+}
+
+// NewToolsetConfig returns the config of a toolset with the given entries. A
+// toolset that only lists tool names keeps them in ToolNames.
+func NewToolsetConfig(name string, selectors []ToolSelector) ToolsetConfig {
+	toolNames := make([]string, 0, len(selectors))
+	for _, sel := range selectors {
+		if sel.Exclude || sel.Tool == "" || isGlob(sel.Tool) {
+			return ToolsetConfig{Name: name, Selectors: selectors}
+		}
+		toolNames = append(toolNames, sel.Tool)
+	}
+	return ToolsetConfig{Name: name, ToolNames: toolNames}
+}
+
+// ResolveToolsets returns toolsets with the tools chosen by their selectors
+// listed in ToolNames.
+func ResolveToolsets(toolsets map[string]ToolsetConfig, toolConfigs map[string]ToolConfig) (map[string]ToolsetConfig, error) {
+	out := make(map[string]ToolsetConfig, len(toolsets))
+	for name, t := range toolsets {
+		if t.Selectors == nil {
+			out[name] = t
+			continue
+		}
+		toolNames, err := ResolveToolset(name, toolsets, toolConfigs)
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve toolset %q: %w", name, err)
+		}
+		out[name] = ToolsetConfig{Name: t.Name, ToolNames: toolNames}
+	}
+	return out, nil
+}
+
+// ResolveToolset returns the names of the tools chosen by the named toolset,
+// in the order they are first selected. Excluded tools are removed last, so
+// they are never selected regardless of where they are listed.
+func ResolveToolset(name string, toolsets map[string]ToolsetConfig, toolConfigs map[string]ToolConfig) ([]string, error) {
+	return resolveToolset(name, toolsets, toolConfigs, nil)
+}
+
+func resolveToolset(name string, toolsets map[string]ToolsetConfig, toolConfigs map[string]ToolConfig, chain []string) ([]string, error) {
+	chain = append(chain, name)
+	if slices.Contains(chain[:len(chain)-1], name) {
+		return nil, fmt.Errorf("%w: %s", ErrToolsetCycle, strings.Join(chain, " -> "))
+	}
+	t, ok := toolsets[name]
+	if !ok {
+		return nil, fmt.Errorf("no toolset named %q configured", name)
+	}
+	if t.Selectors == nil {
+		return t.ToolNames, nil
+	}
+
+	allToolNames := make([]string, 0, len(toolConfigs))
+	for toolName := range toolConfigs {
+		allToolNames = append(allToolNames, toolName)
+	}
+	slices.Sort(allToolNames)
+
+	var included, excluded []string
+	for _, sel := range t.Selectors {
+		var matches []string
+		switch {
+		case sel.Toolset != "":
+			names, err := resolveToolset(sel.Toolset, toolsets, toolConfigs, chain)
+			if err != nil {
+				return nil, err
+			}
+			matches = names
+		case sel.Tool != "" && !isGlob(sel.Tool):
+			matches = []string{sel.Tool}
+		default:
+			for _, toolName := range allToolNames {
+				ok, err := sel.matches(toolName, toolConfigs[toolName])
+				if err != nil {
+					return nil, err
+				}
+				if ok {
+					matches = append(matches, toolName)
+				}
+			}
+		}
+		for _, m := range matches {
+			if sel.Exclude {
+				excluded = append(excluded, m)
+			} else if !slices.Contains(included, m) {
+				included = append(included, m)
+			}
+		}
+	}
+	return slices.DeleteFunc(included, func(n string) bool { return slices.Contains(excluded, n) }), nil
+}
+
+// matches reports whether the tool is chosen by a pattern, kind or source selector.
+func (sel ToolSelector) matches(toolName string, tc ToolConfig) (bool, error) {
+	switch {
+	case sel.Tool != "":
+		return path.Match(sel.Tool, toolName)
+	case sel.Regex != "":
+		re, err := compileRegex(sel.Regex)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(toolName), nil
+	case sel.Kind != "":
+		return tc.ToolConfigKind() == sel.Kind, nil
+	case sel.Source != "":
+		r, ok := tc.(ReferencingToolConfig)
+		return ok && r.ToolConfigReferences().Source == sel.Source, nil
+	default:
+		return false, nil
+	}
 }
 
 type Toolset struct {
@@ -41,7 +245,7 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 	var toolset Toolset
 	toolset.Name = t.Name
 	if !IsValidName(toolset.Name) {
-		return toolset, fmt.Errorf("invalid toolset name: %s", t)
+		return toolset, fmt.Errorf("invalid toolset name: %s", t.Name)
 	}
 	toolset.Tools = make([]*Tool, len(t.ToolNames))
 	toolset.Manifest = ToolsetManifest{
@@ -51,7 +255,7 @@ func (t ToolsetConfig) Initialize(serverVersion string, toolsMap map[string]Tool
 	for _, toolName := range t.ToolNames {
 		tool, ok := toolsMap[toolName]
 		if !ok {
-			return toolset, fmt.Errorf("tool does not exist: %s", t)
+			return toolset, fmt.Errorf("tool does not exist: %s", toolName)
 		}
 		toolset.Tools = append(toolset.Tools, &tool)
 		toolset.Manifest.ToolsManifest[toolName] = tool.Manifest()
@@ -0,0 +1,176 @@
+// Copyright 2025 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tools_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/googleapis/genai-toolbox/internal/sources"
+	"github.com/googleapis/genai-toolbox/internal/tools"
+)
+
+type selectorToolConfig struct {
+	kind   string
+	source string
+}
+
+func (c selectorToolConfig) ToolConfigKind() string {
+	return c.kind
+}
+
+func (selectorToolConfig) Initialize(map[string]sources.Source) (tools.Tool, error) {
+	return nil, nil
+}
+
+func (c selectorToolConfig) ToolConfigReferences() tools.ConfigReferences {
+	return tools.ConfigReferences{Source: c.source}
+}
+
+func mustToolsetConfig(t *testing.T, name string, entries ...any) tools.ToolsetConfig {
+	t.Helper()
+	selectors := make([]tools.ToolSelector, 0, len(entries))
+	for _, e := range entries {
+		sel, err := tools.ParseToolSelector(e)
+		if err != nil {
+			t.Fatalf("unable to parse toolset entry %v: %s", e, err)
+		}
+		selectors = append(selectors, sel)
+	}
+	return tools.NewToolsetConfig(name, selectors)
+}
+
+func TestNewToolsetConfig(t *testing.T) {
+	got := mustToolsetConfig(t, "plain", "tool_a", "tool_b")
+	want := tools.ToolsetConfig{Name: "plain", ToolNames: []string{"tool_a", "tool_b"}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect toolset (-want +got):\n%s", diff)
+	}
+
+	got = mustToolsetConfig(t, "mixed", "tool_a", map[string]any{"exclude": "tool_b"})
+	want = tools.ToolsetConfig{Name: "mixed", Selectors: []tools.ToolSelector{{Tool: "tool_a"}, {Tool: "tool_b", Exclude: true}}}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("incorrect toolset (-want +got):\n%s", diff)
+	}
+}
+
+func TestResolveToolset(t *testing.T) {
+	toolConfigs := map[string]tools.ToolConfig{
+		"orders-list":   selectorToolConfig{kind: "postgres-sql", source: "orders-db"},
+		"orders-delete": selectorToolConfig{kind: "postgres-sql", source: "orders-db"},
+		"orders-export": selectorToolConfig{kind: "http", source: "exporter"},
+		"users-list":    selectorToolConfig{kind: "postgres-sql", source: "users-db"},
+		"search":        selectorToolConfig{kind: "http", source: "search-api"},
+	}
+	toolsets := map[string]tools.ToolsetConfig{
+		"plain":       mustToolsetConfig(t, "plain", "search", "users-list"),
+		"orders":      mustToolsetConfig(t, "orders", "orders-*"),
+		"safe-orders": mustToolsetConfig(t, "safe-orders", map[string]any{"toolset": "orders"}, map[string]any{"exclude": "orders-delete"}),
+		"by-regex":    mustToolsetConfig(t, "by-regex", map[string]any{"regex": "(orders|users)-list"}),
+		"by-partial":  mustToolsetConfig(t, "by-partial", map[string]any{"regex": "list"}, map[string]any{"regex": "orders-(list|delete)"}),
+		"by-kind":     mustToolsetConfig(t, "by-kind", map[string]any{"kind": "http"}),
+		"by-source":   mustToolsetConfig(t, "by-source", map[string]any{"source": "orders-db"}),
+		"combined": mustToolsetConfig(t, "combined",
+			map[string]any{"toolset": "plain"},
+			map[string]any{"toolset": "safe-orders"},
+			"search",
+			map[string]any{"exclude": map[string]any{"kind": "http"}},
+		),
+	}
+
+	tcs := []struct {
+		name string
+		want []string
+	}{
+		{name: "plain", want: []string{"search", "users-list"}},
+		{name: "orders", want: []string{"orders-delete", "orders-export", "orders-list"}},
+		{name: "safe-orders", want: []string{"orders-export", "orders-list"}},
+		{name: "by-regex", want: []string{"orders-list", "users-list"}},
+		// a regex matches whole tool names, not a part of them
+		{name: "by-partial", want: []string{"orders-delete", "orders-list"}},
+		{name: "by-kind", want: []string{"orders-export", "search"}},
+		{name: "by-source", want: []string{"orders-delete", "orders-list"}},
+		{name: "combined", want: []string{"users-list", "orders-list"}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tools.ResolveToolset(tc.name, toolsets, toolConfigs)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("incorrect tools (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	resolved, err := tools.ResolveToolsets(toolsets, toolConfigs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := tools.ToolsetConfig{Name: "safe-orders", ToolNames: []string{"orders-export", "orders-list"}}
+	if diff := cmp.Diff(want, resolved["safe-orders"]); diff != "" {
+		t.Fatalf("incorrect resolved toolset (-want +got):\n%s", diff)
+	}
+}
+
+func TestFailResolveToolset(t *testing.T) {
+	toolsets := map[string]tools.ToolsetConfig{
+		"a":       mustToolsetConfig(t, "a", map[string]any{"toolset": "b"}),
+		"b":       mustToolsetConfig(t, "b", map[string]any{"toolset": "a"}),
+		"missing": mustToolsetConfig(t, "missing", map[string]any{"toolset": "other"}),
+	}
+
+	_, err := tools.ResolveToolset("a", toolsets, nil)
+	if !errors.Is(err, tools.ErrToolsetCycle) {
+		t.Fatalf("expect a cycle error, got %v", err)
+	}
+	if want := "toolsets include each other in a cycle: a -> b -> a"; err.Error() != want {
+		t.Fatalf("unexpected error: got %q, want %q", err, want)
+	}
+
+	_, err = tools.ResolveToolset("missing", toolsets, nil)
+	if want := `no toolset named "other" configured`; err == nil || err.Error() != want {
+		t.Fatalf("unexpected error: got %v, want %q", err, want)
+	}
+}
+
+func TestFailParseToolSelector(t *testing.T) {
+	tcs := []struct {
+		desc string
+		in   any
+		err  string
+	}{
+		{desc: "invalid glob", in: "orders-[", err: `invalid glob pattern "orders-["`},
+		{desc: "invalid regex", in: map[string]any{"regex": "("}, err: `invalid regex "("`},
+		{desc: "unknown key", in: map[string]any{"name": "a"}, err: `unknown toolset entry "name"`},
+		{desc: "several keys", in: map[string]any{"kind": "a", "source": "b"}, err: "a mapping with a single key"},
+		{desc: "nested exclude", in: map[string]any{"exclude": map[string]any{"exclude": "a"}}, err: `invalid exclude: unknown toolset entry "exclude"`},
+		{desc: "not a string", in: map[string]any{"kind": 1}, err: `toolset entry "kind" must be a non-empty string`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.desc, func(t *testing.T) {
+			_, err := tools.ParseToolSelector(tc.in)
+			if err == nil {
+				t.Fatalf("expect parsing to fail")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("unexpected error: got %q, want substring %q", err, tc.err)
+			}
+		})
+	}
+}
@@ -78,6 +78,38 @@ func NewStrictDecoder(v interface{}) (*yaml.Decoder, error) {
 	return dec, nil
 }
 
+// accessTokenKey is the key used to store the caller's OAuth access token within context
+const accessTokenKey contextKey = "accessToken"
+
+// WithAccessToken adds the caller's OAuth access token into the context as a value
+func WithAccessToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, accessTokenKey, token)
+}
+
+// AccessTokenFromContext retrieves the caller's OAuth access token or return an error
+func AccessTokenFromContext(ctx context.Context) (string, error) {
+	if token, ok := ctx.Value(accessTokenKey).(string); ok && token != "" {
+		return token, nil
+	}
+	return "", fmt.Errorf("unable to retrieve access token")
+}
+
+// claimsKey is the key used to store verified auth claims within context
+const claimsKey contextKey = "claims"
+
+// WithClaims adds the claims of each verified auth service into the context as a value
+func WithClaims(ctx context.Context, claimsMap map[string]map[string]any) context.Context {
+	return context.WithValue(ctx, claimsKey, claimsMap)
+}
+
+// ClaimsFromContext retrieves the verified auth claims, or an empty map if none are present
+func ClaimsFromContext(ctx context.Context) map[string]map[string]any {
+	if claimsMap, ok := ctx.Value(claimsKey).(map[string]map[string]any); ok {
+		return claimsMap
+	}
+	return map[string]map[string]any{}
+}
+
 // loggerKey is the key used to store logger within context
 const loggerKey contextKey = "logger"
 
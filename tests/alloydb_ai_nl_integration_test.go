@@ -120,6 +120,7 @@ func runAiNlToolGetTest(t *testing.T) {
 							"name":        "question",
 							"type":        "string",
 							"description": "The natural language question to ask.",
+							"required":    true,
 							"authSources": []any{},
 						},
 					},
@@ -388,7 +389,7 @@ func runAiNlMCPToolCallMethod(t *testing.T) {
 					"arguments": map[string]any{},
 				},
 			},
-			want: `{"jsonrpc":"2.0","id":"invoke-without-parameter","error":{"code":-32602,"message":"provided parameters were invalid: parameter question is required"}}`,
+			want: `{"jsonrpc":"2.0","id":"invoke-without-parameter","error":{"code":-32602,"message":"provided parameters were invalid: parameter \"question\" is required","data":[{"param":"question","reason":"parameter is required"}]}}`,
 		},
 	}
 	for _, tc := range invokeTcs {
@@ -501,7 +501,7 @@ func RunMCPToolCallMethod(t *testing.T, fail_invocation_want string) {
 					"arguments": map[string]any{},
 				},
 			},
-			want: `{"jsonrpc":"2.0","id":"invoke-without-parameter","error":{"code":-32602,"message":"provided parameters were invalid: parameter id is required"}}`,
+			want: `{"jsonrpc":"2.0","id":"invoke-without-parameter","error":{"code":-32602,"message":"provided parameters were invalid: parameter \"id\" is required","data":[{"param":"id","reason":"parameter is required"}]}}`,
 		},
 		{
 			name:          "MCP Invoke my-param-tool with insufficient parameters",
@@ -518,7 +518,7 @@ func RunMCPToolCallMethod(t *testing.T, fail_invocation_want string) {
 					"arguments": map[string]any{"id": 1},
 				},
 			},
-			want: `{"jsonrpc":"2.0","id":"invoke-insufficient-parameter","error":{"code":-32602,"message":"provided parameters were invalid: parameter name is required"}}`,
+			want: `{"jsonrpc":"2.0","id":"invoke-insufficient-parameter","error":{"code":-32602,"message":"provided parameters were invalid: parameter \"name\" is required","data":[{"param":"name","reason":"parameter is required"}]}}`,
 		},
 		{
 			name:          "MCP Invoke my-fail-tool",